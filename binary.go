@@ -0,0 +1,131 @@
+package salesforceid
+
+import "encoding/binary"
+
+// BinarySize is the number of bytes in the binary encoding of a SalesforceID
+// produced by [SalesforceID.MarshalBinary].
+const BinarySize = 12
+
+// headCodec encodes the 7 bytes before the NumericIdentifier.
+var headCodec = Base62{Width: 7, Padded: true}
+
+// MarshalBinary implements [encoding.BinaryMarshaler]. See
+// [SalesforceID.AppendBinary] for the encoding.
+func (s SalesforceID) MarshalBinary() ([]byte, error) {
+	return s.AppendBinary(make([]byte, 0, BinarySize))
+}
+
+// AppendBinary implements [encoding.BinaryAppender]. It appends [BinarySize]
+// big-endian bytes to b: 6 bytes holding the KeyPrefix, PodIdentifier, and
+// Reserved bytes as a Base62 number (shifted left by 2 bits to make room for
+// the Edition) and 6 bytes holding the decoded NumericIdentifier. The suffix
+// is not stored since it is computed from the casing preserved by Base62.
+// Encoded identifiers that share a KeyPrefix, PodIdentifier, and Edition sort
+// in the same order as [Compare].
+func (s SalesforceID) AppendBinary(b []byte) ([]byte, error) {
+	if len(s.id) < 15 {
+		return nil, ErrInvalidBinary
+	}
+	packed, err := packHead(s.id, s.Edition)
+	if err != nil {
+		return nil, err
+	}
+	numeric, err := Decode(s.NumericIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[0:8], packed)
+	binary.BigEndian.PutUint64(buf[8:16], numeric)
+	b = append(b, buf[2:8]...)
+	return append(b, buf[10:16]...), nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler]. It accepts the
+// encoding produced by [SalesforceID.MarshalBinary].
+func (s *SalesforceID) UnmarshalBinary(data []byte) error {
+	if len(data) != BinarySize {
+		return ErrInvalidBinary
+	}
+	var buf [16]byte
+	copy(buf[2:8], data[0:6])
+	copy(buf[10:16], data[6:12])
+	parsed, err := unpack(binary.BigEndian.Uint64(buf[0:8]), binary.BigEndian.Uint64(buf[8:16]))
+	if err != nil {
+		return err
+	}
+	*s = *parsed
+	return nil
+}
+
+// packHead returns the Base62 value of the KeyPrefix, PodIdentifier, and
+// Reserved bytes of id shifted left by 2 bits and combined with edition.
+func packHead(id []byte, edition IdentifierEdition) (uint64, error) {
+	head, err := headCodec.Decode(id[:7])
+	if err != nil {
+		return 0, ErrInvalidBinary
+	}
+	switch edition {
+	case PreSummer23IdentifierEdition:
+		return head<<2 | 1, nil
+	case PostSummer23IdentifierEdition:
+		return head<<2 | 2, nil
+	}
+	return 0, ErrInvalidEdition
+}
+
+// unpack rebuilds the identifier from a head packed by packHead and a decoded
+// NumericIdentifier.
+func unpack(packed, numeric uint64) (*SalesforceID, error) {
+	var edition IdentifierEdition
+	switch packed & 0b11 {
+	case 1:
+		edition = PreSummer23IdentifierEdition
+	case 2:
+		edition = PostSummer23IdentifierEdition
+	default:
+		return nil, ErrInvalidBinary
+	}
+	id := make([]byte, 0, 18)
+	id, err := headCodec.AppendEncode(id, packed>>2)
+	if err != nil {
+		return nil, ErrInvalidBinary
+	}
+	id, err = identifierCodec.AppendEncode(id, numeric)
+	if err != nil {
+		return nil, ErrInvalidBinary
+	}
+	id = id[:18]
+	suffix := checkSuffix(id[:15])
+	copy(id[15:], suffix[:])
+	if checkFields(id, edition) != nil {
+		return nil, ErrInvalidBinary
+	}
+	return fromBytes(id, edition)
+}
+
+// AppendNumericVarint appends the decoded NumericIdentifier of s to dst as
+// an unsigned varint. Identifiers that share a KeyPrefix and PodIdentifier
+// can be stored as varints and rebuilt with [SalesforceID.WithNumericVarint].
+func (s *SalesforceID) AppendNumericVarint(dst []byte) ([]byte, error) {
+	numeric, err := Decode(s.NumericIdentifier)
+	if err != nil {
+		return nil, err
+	}
+	return binary.AppendUvarint(dst, numeric), nil
+}
+
+// WithNumericVarint reads an unsigned varint from src written by
+// [SalesforceID.AppendNumericVarint] and returns a copy of s with that
+// NumericIdentifier along with the number of bytes read.
+func (s *SalesforceID) WithNumericVarint(src []byte) (*SalesforceID, int, error) {
+	numeric, n := binary.Uvarint(src)
+	if n <= 0 {
+		return nil, 0, ErrInvalidBinary
+	}
+	id, err := s.withNumeric(numeric)
+	if err != nil {
+		return nil, 0, err
+	}
+	return id, n, nil
+}
@@ -0,0 +1,123 @@
+package salesforceid_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sigmavirus24/salesforceid"
+)
+
+func TestSalesforceID_MarshalBinary(t *testing.T) {
+	testCases := []struct {
+		sfid    string
+		edition salesforceid.IdentifierEdition
+	}{
+		{"00D000000000062", salesforceid.PreSummer23IdentifierEdition},
+		{"00d000000000062eaa", salesforceid.PostSummer23IdentifierEdition},
+		{"0011Ab000000062", salesforceid.PostSummer23IdentifierEdition},
+		{"0A3D0000001aH2A", salesforceid.PreSummer23IdentifierEdition},
+		{"zzzzzz0zzzzzzzz", salesforceid.PostSummer23IdentifierEdition},
+		{"000000000000000", salesforceid.PreSummer23IdentifierEdition},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.sfid, func(t *testing.T) {
+			want, err := salesforceid.Parse(tc.sfid, tc.edition)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tc.sfid, err)
+			}
+			data, err := want.MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary() error = %v", err)
+			}
+			if len(data) != salesforceid.BinarySize {
+				t.Errorf("len(MarshalBinary()) = %d, want %d", len(data), salesforceid.BinarySize)
+			}
+			var got salesforceid.SalesforceID
+			if err := got.UnmarshalBinary(data); err != nil {
+				t.Fatalf("UnmarshalBinary() error = %v", err)
+			}
+			if diff := cmp.Diff(&got, want, compareFields); diff != "" {
+				t.Errorf("UnmarshalBinary() = %+v, want %+v, diff = %s", got, want, diff)
+			}
+		})
+	}
+}
+
+func TestSalesforceID_MarshalBinary_order(t *testing.T) {
+	ids := []string{"001000000000009", "00100000000000Z", "00100000000000a", "001000000000010", "0010000zzzzzzzz"}
+	var previous []byte
+	for _, sfid := range ids {
+		id := mustNew(t, sfid)
+		data, err := id.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() error = %v", err)
+		}
+		if previous != nil && bytes.Compare(previous, data) >= 0 {
+			t.Errorf("expected encoding of %s to sort after the previous identifier", sfid)
+		}
+		previous = data
+	}
+}
+
+func TestSalesforceID_UnmarshalBinary(t *testing.T) {
+	outOfRange := make([]byte, salesforceid.BinarySize)
+	binary.BigEndian.PutUint16(outOfRange[0:2], 0xffff)
+	outOfRange[5] = 1
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"too short", make([]byte, salesforceid.BinarySize-1)},
+		{"too long", make([]byte, salesforceid.BinarySize+1)},
+		{"no edition", make([]byte, salesforceid.BinarySize)},
+		{"head out of range", outOfRange},
+		{"numeric out of range", []byte{0, 0, 0, 0, 0, 1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got salesforceid.SalesforceID
+			if err := got.UnmarshalBinary(tc.data); !errors.Is(err, salesforceid.ErrInvalidBinary) {
+				t.Errorf("expected err %q but got %v", salesforceid.ErrInvalidBinary, err)
+			}
+		})
+	}
+}
+
+func TestSalesforceID_NumericVarint(t *testing.T) {
+	template, _ := salesforceid.Parse("0011Ab000000000", salesforceid.PostSummer23IdentifierEdition)
+	var buf []byte
+	var want []*salesforceid.SalesforceID
+	for _, sfid := range []string{"0011Ab000000062", "0011Ab00000000z", "0011Ab0zzzzzzzz"} {
+		id, _ := salesforceid.Parse(sfid, salesforceid.PostSummer23IdentifierEdition)
+		want = append(want, id)
+		var err error
+		buf, err = id.AppendNumericVarint(buf)
+		if err != nil {
+			t.Fatalf("AppendNumericVarint() error = %v", err)
+		}
+	}
+
+	var got []*salesforceid.SalesforceID
+	for len(buf) > 0 {
+		id, n, err := template.WithNumericVarint(buf)
+		if err != nil {
+			t.Fatalf("WithNumericVarint() error = %v", err)
+		}
+		got = append(got, id)
+		buf = buf[n:]
+	}
+	if diff := cmp.Diff(got, want, compareFields); diff != "" {
+		t.Errorf("WithNumericVarint() = %v, want %v, diff = %s", got, want, diff)
+	}
+
+	if _, _, err := template.WithNumericVarint(nil); !errors.Is(err, salesforceid.ErrInvalidBinary) {
+		t.Errorf("expected err %q but got %v", salesforceid.ErrInvalidBinary, err)
+	}
+}
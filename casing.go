@@ -0,0 +1,86 @@
+package salesforceid
+
+import "iter"
+
+// CaseCandidateCount returns the number of identifiers [CaseCandidates]
+// yields for id, i.e., 2 to the power of the number of letters in a 15
+// character id, or 1 otherwise.
+func CaseCandidateCount(id string) uint64 {
+	if len(id) != 15 {
+		return 1
+	}
+	letters := 0
+	for i := range len(id) {
+		if isLetter(id[i]) {
+			letters++
+		}
+	}
+	return 1 << letters
+}
+
+// CaseCandidates returns an iterator over every casing of a 15 character id
+// whose case was lost, e.g., by a case-insensitive database. Candidates are
+// yielded starting with every letter lower case and counting up in binary
+// where the first letter is the least significant bit, so there are at most
+// 2^15 of them. See [CaseCandidateCount].
+//
+// An 18 character id keeps its case in its check bytes so only the identifier
+// returned by [Parse] is yielded. If id cannot be parsed, the error is yielded
+// and iteration stops.
+func CaseCandidates(id string, edition IdentifierEdition) iter.Seq2[*SalesforceID, error] {
+	return func(yield func(*SalesforceID, error) bool) {
+		if len(id) != 15 {
+			yield(Parse(id, edition))
+			return
+		}
+		var letters []int
+		candidate := []byte(id)
+		for i, b := range candidate {
+			if isLetter(b) {
+				letters = append(letters, i)
+				candidate[i] = lower(b)
+			}
+		}
+		for mask := range uint32(1) << len(letters) {
+			for bit, i := range letters {
+				if mask&(1<<bit) != 0 {
+					candidate[i] = upper(candidate[i])
+				} else {
+					candidate[i] = lower(candidate[i])
+				}
+			}
+			s, err := Parse(string(candidate), edition)
+			if !yield(s, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// ResolveCase returns the candidates yielded by [CaseCandidates] for which
+// lookup returns true. Use [IDSet.Contains] as lookup to resolve id against
+// a set of known identifiers. More than one identifier is returned if the
+// case of id is ambiguous.
+func ResolveCase(id string, edition IdentifierEdition, lookup func(*SalesforceID) bool) ([]*SalesforceID, error) {
+	var found []*SalesforceID
+	for candidate, err := range CaseCandidates(id, edition) {
+		if err != nil {
+			return nil, err
+		}
+		if lookup(candidate) {
+			found = append(found, candidate)
+		}
+	}
+	return found, nil
+}
+
+func isLetter(b byte) bool {
+	return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+func lower(b byte) byte {
+	if 'A' <= b && b <= 'Z' {
+		return b + 32
+	}
+	return b
+}
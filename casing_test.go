@@ -0,0 +1,88 @@
+package salesforceid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sigmavirus24/salesforceid"
+)
+
+func TestCaseCandidates(t *testing.T) {
+	testCases := []struct {
+		sfid      string
+		wantCount uint64
+		wantFirst []string
+		wantErr   error
+	}{
+		{"001000000000062", 1, []string{"001000000000062AAA"}, nil},
+		{"00d000000000062", 2, []string{"00d000000000062AAA", "00D000000000062EAA"}, nil},
+		{"003D0000001AH2A", 16, []string{"003d0000001ah2aAAA", "003D0000001ah2aIAA", "003d0000001Ah2aAAC"}, nil},
+		{"00d000000000062eaa", 1, []string{"00D000000000062EAA"}, nil},
+		{"00d00000000006", 1, nil, salesforceid.ErrInvalidLengthSFID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.sfid, func(t *testing.T) {
+			if got := salesforceid.CaseCandidateCount(tc.sfid); got != tc.wantCount {
+				t.Errorf("CaseCandidateCount(%q) = %d, want %d", tc.sfid, got, tc.wantCount)
+			}
+			var got []string
+			seen := map[string]bool{}
+			for id, err := range salesforceid.CaseCandidates(tc.sfid, salesforceid.PreSummer23IdentifierEdition) {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected err %q but got %q", tc.wantErr, err)
+				}
+				if err != nil {
+					continue
+				}
+				seen[id.String()] = true
+				if len(got) < len(tc.wantFirst) {
+					got = append(got, id.String())
+				}
+			}
+			if diff := cmp.Diff(tc.wantFirst, got); diff != "" {
+				t.Errorf("CaseCandidates(%q) mismatch (-want +got):\n%s", tc.sfid, diff)
+			}
+			if tc.wantErr == nil && uint64(len(seen)) != tc.wantCount {
+				t.Errorf("CaseCandidates(%q) yielded %d distinct identifiers, want %d", tc.sfid, len(seen), tc.wantCount)
+			}
+		})
+	}
+}
+
+func TestResolveCase(t *testing.T) {
+	known := &salesforceid.IDSet{}
+	for _, id := range []string{"003D0000001aH2A", "00d000000000062", "00D000000000062"} {
+		if err := known.Add(mustNew(t, id)); err != nil {
+			t.Fatalf("didn't expect an error but got %q", err)
+		}
+	}
+	testCases := []struct {
+		sfid    string
+		want    []string
+		wantErr error
+	}{
+		{"003d0000001ah2a", []string{"003D0000001aH2AIAU"}, nil},
+		{"003D0000001AH2A", []string{"003D0000001aH2AIAU"}, nil},
+		{"00D000000000062", []string{"00d000000000062AAA", "00D000000000062EAA"}, nil},
+		{"001000000000062", nil, nil},
+		{"00100000000006", nil, salesforceid.ErrInvalidLengthSFID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.sfid, func(t *testing.T) {
+			found, err := salesforceid.ResolveCase(tc.sfid, salesforceid.PreSummer23IdentifierEdition, known.Contains)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected err %q but got %q", tc.wantErr, err)
+			}
+			var got []string
+			for _, id := range found {
+				got = append(got, id.String())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ResolveCase(%q) mismatch (-want +got):\n%s", tc.sfid, diff)
+			}
+		})
+	}
+}
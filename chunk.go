@@ -0,0 +1,40 @@
+package salesforceid
+
+import "iter"
+
+// Chunk splits the inclusive range from start to end into consecutive ranges
+// of at most size identifiers for primary key chunking. The last range ends
+// at end even if it is smaller than size. If the arguments are invalid a
+// single error is yielded: [ErrInvalidChunkSize] if size is 0,
+// [ErrMismatchedRange] if start and end differ before the NumericIdentifier,
+// and [ErrInvalidRange] if start is nil, end is nil, or start is after end.
+//
+//	for r, err := range salesforceid.Chunk(start, end, 250_000) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Printf("SELECT Id FROM Account WHERE Id >= '%s' AND Id <= '%s'\n", r.Start, r.End)
+//	}
+func Chunk(start, end *SalesforceID, size uint64) iter.Seq2[IDRange, error] {
+	return func(yield func(IDRange, error) bool) {
+		lo, hi, err := rangeBounds(start, end)
+		if err == nil && size == 0 {
+			err = ErrInvalidChunkSize
+		}
+		if err != nil {
+			yield(IDRange{}, err)
+			return
+		}
+		for {
+			chunkEnd := hi
+			if size-1 < hi-lo {
+				chunkEnd = lo + size - 1
+			}
+			r, err := start.rangeOf(lo, chunkEnd)
+			if !yield(r, err) || err != nil || chunkEnd == hi {
+				return
+			}
+			lo = chunkEnd + 1
+		}
+	}
+}
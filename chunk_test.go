@@ -0,0 +1,141 @@
+package salesforceid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sigmavirus24/salesforceid"
+)
+
+func TestChunk(t *testing.T) {
+	testCases := []struct {
+		name    string
+		start   string
+		end     string
+		size    uint64
+		want    [][2]string
+		wantErr error
+	}{
+		{
+			name:  "evenly divided",
+			start: "001000000000000",
+			end:   "00100000000000z",
+			size:  31,
+			want: [][2]string{
+				{"001000000000000AAA", "00100000000000UAAQ"},
+				{"00100000000000VAAQ", "00100000000000zAAA"},
+			},
+		},
+		{
+			name:  "last chunk is smaller",
+			start: "001000000000000",
+			end:   "001000000000010",
+			size:  50,
+			want: [][2]string{
+				{"001000000000000AAA", "00100000000000nAAA"},
+				{"00100000000000oAAA", "001000000000010AAA"},
+			},
+		},
+		{
+			name:  "single identifier",
+			start: "001000000000062",
+			end:   "001000000000062",
+			size:  250_000,
+			want:  [][2]string{{"001000000000062AAA", "001000000000062AAA"}},
+		},
+		{
+			name:  "ends at the largest numeric identifier",
+			start: "0010000zzzzzzzy",
+			end:   "0010000zzzzzzzz",
+			size:  1,
+			want: [][2]string{
+				{"0010000zzzzzzzyAAA", "0010000zzzzzzzyAAA"},
+				{"0010000zzzzzzzzAAA", "0010000zzzzzzzzAAA"},
+			},
+		},
+		{
+			name:  "size larger than the range",
+			start: "0010000zzzzzzzy",
+			end:   "0010000zzzzzzzz",
+			size:  salesforceid.MaxIdentifierValue * 2,
+			want:  [][2]string{{"0010000zzzzzzzyAAA", "0010000zzzzzzzzAAA"}},
+		},
+		{
+			name:    "zero size",
+			start:   "001000000000000",
+			end:     "00100000000000z",
+			size:    0,
+			wantErr: salesforceid.ErrInvalidChunkSize,
+		},
+		{
+			name:    "start after end",
+			start:   "00100000000000z",
+			end:     "001000000000000",
+			size:    1,
+			wantErr: salesforceid.ErrInvalidRange,
+		},
+		{
+			name:    "different key prefixes",
+			start:   "001000000000000",
+			end:     "00300000000000z",
+			size:    1,
+			wantErr: salesforceid.ErrMismatchedRange,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start, _ := salesforceid.New(tc.start)
+			end, _ := salesforceid.New(tc.end)
+			var got [][2]string
+			for r, err := range salesforceid.Chunk(start, end, tc.size) {
+				if err != nil {
+					if !errors.Is(err, tc.wantErr) {
+						t.Fatalf("expected err %q but got %q", tc.wantErr, err)
+					}
+					return
+				}
+				got = append(got, [2]string{r.Start.String(), r.End.String()})
+			}
+			if tc.wantErr != nil {
+				t.Fatalf("expected err %q but got nil", tc.wantErr)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("wanted %d chunks, got %d: %v", len(tc.want), len(got), got)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("chunk %d = %v, want %v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestChunk_editions(t *testing.T) {
+	start, _ := salesforceid.Parse("0011Ab000000000", salesforceid.PostSummer23IdentifierEdition)
+	end, _ := salesforceid.Parse("0011Ab00000000z", salesforceid.PostSummer23IdentifierEdition)
+	for r, err := range salesforceid.Chunk(start, end, 10) {
+		if err != nil {
+			t.Fatalf("didn't expect an error but got %q", err)
+		}
+		if r.Start.Edition != salesforceid.PostSummer23IdentifierEdition || r.End.Edition != salesforceid.PostSummer23IdentifierEdition {
+			t.Errorf("expected chunk %s-%s to keep the edition of start", r.Start, r.End)
+		}
+	}
+}
+
+func TestChunk_stop(t *testing.T) {
+	start, _ := salesforceid.New("001000000000000")
+	end, _ := salesforceid.New("0010000zzzzzzzz")
+	count := 0
+	for range salesforceid.Chunk(start, end, 1) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	if count != 3 {
+		t.Errorf("wanted to stop after 3 chunks, got %d", count)
+	}
+}
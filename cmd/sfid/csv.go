@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sigmavirus24/salesforceid"
+)
+
+func runCSV(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("sfid csv", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	edition := editionFlag(salesforceid.AutoIdentifierEdition)
+	fs.Var(&edition, "edition", "identifier edition: pre, post, or auto")
+	columns := fs.String("columns", "", "comma separated names (or 1-based numbers with -no-header) of identifier columns; detected if empty")
+	noHeader := fs.Bool("no-header", false, "the first record is not a header")
+	comma := fs.String("comma", ",", "field delimiter")
+	detectRows := fs.Int("detect-rows", salesforceid.DefaultCSVDetectRows, "number of records sampled to detect identifier columns")
+	output := fs.String("o", "", "write to this file instead of standard output")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: sfid csv [flags] [FILE]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if utf8.RuneCountInString(*comma) != 1 || fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+
+	src := stdin
+	if fs.NArg() == 1 {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			fmt.Fprintf(stderr, "sfid: %s\n", err)
+			return 1
+		}
+		defer f.Close()
+		src = f
+	}
+	dst := stdout
+	var out *os.File
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(stderr, "sfid: %s\n", err)
+			return 1
+		}
+		out, dst = f, f
+	}
+
+	opts := salesforceid.CSVOptions{
+		Header:     !*noHeader,
+		DetectRows: *detectRows,
+		Comma:      []rune(*comma)[0],
+		Edition:    salesforceid.IdentifierEdition(edition),
+	}
+	if *columns != "" {
+		opts.Columns = strings.Split(*columns, ",")
+	}
+	invalid := 0
+	opts.OnInvalid = func(err *salesforceid.CSVCellError) {
+		invalid++
+		fmt.Fprintf(stderr, "sfid: %s\n", err)
+	}
+	err := salesforceid.NormalizeCSV(dst, src, opts)
+	// Close errors are reported since they may mean the output was not
+	// completely written.
+	if out != nil {
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "sfid: %s\n", err)
+		return 1
+	}
+	if invalid > 0 {
+		return 1
+	}
+	return 0
+}
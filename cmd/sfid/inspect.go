@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/sigmavirus24/salesforceid"
+)
+
+type inspection struct {
+	Input             string `json:"input"`
+	ID                string `json:"id,omitempty"`
+	ID15              string `json:"id15,omitempty"`
+	Edition           string `json:"edition,omitempty"`
+	KeyPrefix         string `json:"keyPrefix,omitempty"`
+	ObjectType        string `json:"objectType,omitempty"`
+	PodIdentifier     string `json:"podIdentifier,omitempty"`
+	Instance          string `json:"instance,omitempty"`
+	Reserved          string `json:"reserved,omitempty"`
+	NumericIdentifier string `json:"numericIdentifier,omitempty"`
+	NumericValue      uint64 `json:"numericValue"`
+	Suffix            string `json:"suffix,omitempty"`
+	Error             string `json:"error,omitempty"`
+}
+
+func inspect(input string, edition salesforceid.IdentifierEdition) (inspection, error) {
+	result := inspection{Input: input}
+	id, err := salesforceid.Parse(input, edition)
+	if err != nil {
+		result.Error = err.Error()
+		return result, err
+	}
+	result.ID = id.String()
+	result.ID15 = id.Format(salesforceid.FifteenCharacterFormat)
+	result.Edition = editionName(id.Edition)
+	result.KeyPrefix = string(id.KeyPrefix)
+	result.ObjectType, _ = id.ObjectType()
+	result.PodIdentifier = string(id.PodIdentifier)
+	result.Instance, _ = id.Instance()
+	result.Reserved = string(id.Reserved)
+	result.NumericIdentifier = string(id.NumericIdentifier)
+	result.Suffix = string(id.Suffix)
+	result.NumericValue, err = salesforceid.Decode(id.NumericIdentifier)
+	if err != nil {
+		result.Error = err.Error()
+		return result, err
+	}
+	return result, nil
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+func runInspect(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs, edition, asJSON := newFlagSet("inspect", stderr)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	status := 0
+	enc := json.NewEncoder(stdout)
+	err := inputs(fs.Args(), stdin, true, func(input string) {
+		result, err := inspect(input, salesforceid.IdentifierEdition(*edition))
+		if err != nil {
+			status = 1
+		}
+		switch {
+		case *asJSON:
+			_ = enc.Encode(result)
+		case err != nil:
+			fmt.Fprintf(stderr, "sfid: %s: %s\n", input, err)
+		default:
+			fmt.Fprintf(stdout, "ID:                 %s\n", result.ID)
+			fmt.Fprintf(stdout, "15 character ID:    %s\n", result.ID15)
+			fmt.Fprintf(stdout, "Edition:            %s\n", result.Edition)
+			fmt.Fprintf(stdout, "Key prefix:         %s (%s)\n", result.KeyPrefix, orUnknown(result.ObjectType))
+			fmt.Fprintf(stdout, "Pod identifier:     %s (%s)\n", result.PodIdentifier, orUnknown(result.Instance))
+			fmt.Fprintf(stdout, "Reserved:           %s\n", result.Reserved)
+			fmt.Fprintf(stdout, "Numeric identifier: %s (%d)\n", result.NumericIdentifier, result.NumericValue)
+			fmt.Fprintf(stdout, "Suffix:             %s\n\n", result.Suffix)
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "sfid: %s\n", err)
+		return 1
+	}
+	return status
+}
@@ -0,0 +1,176 @@
+// Command sfid converts and inspects Salesforce identifiers.
+//
+// Usage:
+//
+//	sfid [convert] [-15] [-json] [-edition pre|post|auto] [ID...]
+//	sfid inspect [-json] [-edition pre|post|auto] [ID...]
+//	sfid csv [-columns NAME,...] [-no-header] [-comma ,] [-o FILE] [FILE]
+//	sfid repair [-json] [-edition pre|post|auto] [ID...]
+//
+// If no identifiers are given as arguments they are read from standard input,
+// one per line. The csv command reads CSV from FILE or standard input and
+// writes it with every identifier in the selected or detected columns in its
+// 18 character form. The repair command prints each identifier damaged by a
+// spreadsheet, whether its repair is certain or ambiguous, and the
+// identifiers it could have been.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/sigmavirus24/salesforceid"
+)
+
+const usage = `usage: sfid <command> [flags] [ID...]
+
+Commands:
+  convert   convert identifiers between 15 and 18 characters (default)
+  inspect   print the components of identifiers
+  csv       normalize identifier columns of a CSV file to 18 characters
+  repair    reconstruct identifiers damaged by spreadsheets
+
+Identifiers are read from standard input, one per line, if none are given.
+Run "sfid <command> -h" for the flags of a command.
+`
+
+type command func(args []string, stdin io.Reader, stdout, stderr io.Writer) int
+
+var commands = map[string]command{
+	"convert": runConvert,
+	"inspect": runInspect,
+	"csv":     runCSV,
+	"repair":  runRepair,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	name := "convert"
+	if len(args) > 0 {
+		if _, ok := commands[args[0]]; ok {
+			name, args = args[0], args[1:]
+		} else if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+			fmt.Fprint(stdout, usage)
+			return 0
+		}
+	}
+	return commands[name](args, stdin, stdout, stderr)
+}
+
+// editionFlag parses the -edition flag into a salesforceid.IdentifierEdition.
+type editionFlag salesforceid.IdentifierEdition
+
+func (e *editionFlag) String() string {
+	return editionName(salesforceid.IdentifierEdition(*e))
+}
+
+func (e *editionFlag) Set(s string) error {
+	switch s {
+	case "pre":
+		*e = editionFlag(salesforceid.PreSummer23IdentifierEdition)
+	case "post":
+		*e = editionFlag(salesforceid.PostSummer23IdentifierEdition)
+	case "auto":
+		*e = editionFlag(salesforceid.AutoIdentifierEdition)
+	default:
+		return fmt.Errorf("unknown edition %q: must be pre, post, or auto", s)
+	}
+	return nil
+}
+
+func editionName(e salesforceid.IdentifierEdition) string {
+	switch e {
+	case salesforceid.PreSummer23IdentifierEdition:
+		return "pre"
+	case salesforceid.PostSummer23IdentifierEdition:
+		return "post"
+	case salesforceid.AutoIdentifierEdition:
+		return "auto"
+	default:
+		return fmt.Sprintf("unknown(%d)", e)
+	}
+}
+
+func newFlagSet(name string, stderr io.Writer) (*flag.FlagSet, *editionFlag, *bool) {
+	fs := flag.NewFlagSet("sfid "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	edition := editionFlag(salesforceid.AutoIdentifierEdition)
+	fs.Var(&edition, "edition", "identifier edition: pre, post, or auto")
+	asJSON := fs.Bool("json", false, "print one JSON object per identifier")
+	return fs, &edition, asJSON
+}
+
+// inputs calls fn with every identifier in args or, if there are none, every
+// non-blank line of stdin with surrounding whitespace removed if trim is set.
+func inputs(args []string, stdin io.Reader, trim bool, fn func(string)) error {
+	if len(args) > 0 {
+		for _, arg := range args {
+			fn(arg)
+		}
+		return nil
+	}
+	scanner := bufio.NewScanner(stdin)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if trim {
+			line = strings.TrimSpace(line)
+		}
+		fn(line)
+	}
+	return scanner.Err()
+}
+
+type convertResult struct {
+	Input string `json:"input"`
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+func runConvert(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs, edition, asJSON := newFlagSet("convert", stderr)
+	fifteen := fs.Bool("15", false, "print 15 character identifiers instead of 18")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	format := salesforceid.EighteenCharacterFormat
+	if *fifteen {
+		format = salesforceid.FifteenCharacterFormat
+	}
+
+	status := 0
+	enc := json.NewEncoder(stdout)
+	err := inputs(fs.Args(), stdin, true, func(input string) {
+		result := convertResult{Input: input}
+		id, err := salesforceid.Parse(input, salesforceid.IdentifierEdition(*edition))
+		if err != nil {
+			status = 1
+			result.Error = err.Error()
+		} else {
+			result.ID = id.Format(format)
+		}
+		switch {
+		case *asJSON:
+			_ = enc.Encode(result)
+		case err != nil:
+			fmt.Fprintf(stderr, "sfid: %s: %s\n", input, err)
+		default:
+			fmt.Fprintln(stdout, result.ID)
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "sfid: %s\n", err)
+		return 1
+	}
+	return status
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		name       string
+		args       []string
+		stdin      string
+		wantStatus int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "convert is the default command",
+			args:       []string{"00D000000000062", "00d000000000062eaa"},
+			wantStdout: "00D000000000062EAA\n00D000000000062EAA\n",
+		},
+		{
+			name:       "convert to 15 characters",
+			args:       []string{"convert", "-15", "00d000000000062eaa"},
+			wantStdout: "00D000000000062\n",
+		},
+		{
+			name:       "convert from stdin",
+			args:       []string{"convert"},
+			stdin:      "00D000000000062\n\n  001000000000000  \n",
+			wantStdout: "00D000000000062EAA\n001000000000000AAA\n",
+		},
+		{
+			name:       "convert reports invalid identifiers",
+			args:       []string{"00D000000000062", "00D00000000006"},
+			wantStatus: 1,
+			wantStdout: "00D000000000062EAA\n",
+			wantStderr: "sfid: 00D00000000006: parsing \"00D00000000006\": sfids should be 15 or 18 characters: got 14 characters\n",
+		},
+		{
+			name:       "convert as JSON",
+			args:       []string{"-json", "00D000000000062", "00D00000000006"},
+			wantStatus: 1,
+			wantStdout: `{"input":"00D000000000062","id":"00D000000000062EAA"}` + "\n" +
+				`{"input":"00D00000000006","error":"parsing \"00D00000000006\": sfids should be 15 or 18 characters: got 14 characters"}` + "\n",
+		},
+		{
+			name: "inspect",
+			args: []string{"inspect", "00130000000abCd"},
+			wantStdout: "ID:                 00130000000abCdAAI\n" +
+				"15 character ID:    00130000000abCd\n" +
+				"Edition:            pre\n" +
+				"Key prefix:         001 (Account)\n" +
+				"Pod identifier:     30 (NA1)\n" +
+				"Reserved:           00\n" +
+				"Numeric identifier: 0000abCd (8722819)\n" +
+				"Suffix:             AAI\n\n",
+		},
+		{
+			name: "inspect as JSON",
+			args: []string{"inspect", "-json", "-edition", "post", "0011Ab000000062"},
+			wantStdout: `{"input":"0011Ab000000062","id":"0011Ab000000062QAA","id15":"0011Ab000000062",` +
+				`"edition":"post","keyPrefix":"001","objectType":"Account","podIdentifier":"1Ab",` +
+				`"reserved":"0","numericIdentifier":"00000062","numericValue":374,"suffix":"QAA"}` + "\n",
+		},
+		{
+			name:       "csv with selected columns",
+			args:       []string{"csv", "-columns", "Id"},
+			stdin:      "Id,Name\n00d000000000062eaa,Acme\n00D00000000006,Initech\n",
+			wantStatus: 1,
+			wantStdout: "Id,Name\n00D000000000062EAA,Acme\n00D00000000006,Initech\n",
+			wantStderr: "sfid: row 3, column 1 (Id): \"00D00000000006\": parsing \"00D00000000006\": sfids should be 15 or 18 characters: got 14 characters\n",
+		},
+		{
+			name:       "repair",
+			args:       []string{"repair", "1000000000062", " 00D000000000062 ", "1.00000000006E+12", "1E+12"},
+			wantStatus: 1,
+			wantStdout: "1000000000062\tcertain\t001000000000062AAA\n" +
+				" 00D000000000062 \tcertain\t00D000000000062EAA\n" +
+				"1.00000000006E+12\tambiguous\t001000000000055AAA 001000000000056AAA 001000000000057AAA 001000000000058AAA 001000000000059AAA " +
+				"001000000000060AAA 001000000000061AAA 001000000000062AAA 001000000000063AAA 001000000000064AAA\n",
+			wantStderr: "sfid: identifier could not be repaired: \"1E+12\"\n",
+		},
+		{
+			name:  "repair from stdin",
+			args:  []string{"repair", "-json"},
+			stdin: " 00D000000000062\n\n1000000000062\n",
+			wantStdout: `{"input":" 00D000000000062","candidates":["00D000000000062EAA"],"repairs":["whitespace"],"certain":true}` + "\n" +
+				`{"input":"1000000000062","candidates":["001000000000062AAA"],"repairs":["leading zeros"],"certain":true}` + "\n",
+		},
+		{
+			name:       "repair as JSON",
+			args:       []string{"repair", "-json", "1000000000062", "1E+12"},
+			wantStatus: 1,
+			wantStdout: `{"input":"1000000000062","candidates":["001000000000062AAA"],"repairs":["leading zeros"],"certain":true}` + "\n" +
+				`{"input":"1E+12","certain":false,"error":"identifier could not be repaired: \"1E+12\""}` + "\n",
+		},
+		{
+			name:       "csv with detected columns",
+			args:       []string{"csv", "-no-header", "-comma", ";"},
+			stdin:      "00D000000000062;Acme;001000000000000\n",
+			wantStdout: "00D000000000062EAA;Acme;001000000000000AAA\n",
+		},
+		{
+			name:       "csv with invalid delimiter",
+			args:       []string{"csv", "-comma", ";;"},
+			wantStatus: 2,
+		},
+		{
+			name:       "invalid edition",
+			args:       []string{"inspect", "-edition", "winter24", "0011Ab000000062"},
+			wantStatus: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			status := run(tc.args, strings.NewReader(tc.stdin), &stdout, &stderr)
+			if status != tc.wantStatus {
+				t.Errorf("run(%v) = %d, want %d (stderr: %s)", tc.args, status, tc.wantStatus, stderr.String())
+			}
+			if got := stdout.String(); got != tc.wantStdout {
+				t.Errorf("run(%v) stdout = %q, want %q", tc.args, got, tc.wantStdout)
+			}
+			if tc.wantStderr != "" && stderr.String() != tc.wantStderr {
+				t.Errorf("run(%v) stderr = %q, want %q", tc.args, stderr.String(), tc.wantStderr)
+			}
+		})
+	}
+}
+
+func TestRun_csvOutputFile(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "out.csv")
+	var stdout, stderr bytes.Buffer
+	args := []string{"csv", "-o", output}
+	status := run(args, strings.NewReader("Id\n00d000000000062eaa\n"), &stdout, &stderr)
+	if status != 0 {
+		t.Fatalf("run(%v) = %d, want 0 (stderr: %s)", args, status, stderr.String())
+	}
+	got, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("didn't expect an error but got %q", err)
+	}
+	if string(got) != "Id\n00D000000000062EAA\n" {
+		t.Errorf("run(%v) wrote %q, want %q", args, got, "Id\n00D000000000062EAA\n")
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("run(%v) stdout = %q, want nothing", args, stdout.String())
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sigmavirus24/salesforceid"
+)
+
+type repairResult struct {
+	Input      string   `json:"input"`
+	Candidates []string `json:"candidates,omitempty"`
+	Repairs    []string `json:"repairs,omitempty"`
+	Certain    bool     `json:"certain"`
+	Error      string   `json:"error,omitempty"`
+}
+
+func runRepair(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs, edition, asJSON := newFlagSet("repair", stderr)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	status := 0
+	enc := json.NewEncoder(stdout)
+	// Lines are not trimmed so that whitespace added by a spreadsheet is
+	// reported as a repair.
+	err := inputs(fs.Args(), stdin, false, func(input string) {
+		result := repairResult{Input: input}
+		repaired, err := salesforceid.Repair(input, salesforceid.IdentifierEdition(*edition))
+		if err != nil {
+			status = 1
+			result.Error = err.Error()
+		}
+		for _, id := range repaired.Candidates {
+			result.Candidates = append(result.Candidates, id.String())
+		}
+		for _, kind := range repaired.Repairs {
+			result.Repairs = append(result.Repairs, kind.String())
+		}
+		result.Certain = repaired.Certain
+		switch {
+		case *asJSON:
+			_ = enc.Encode(result)
+		case err != nil:
+			fmt.Fprintf(stderr, "sfid: %s\n", err)
+		default:
+			certainty := "ambiguous"
+			if result.Certain {
+				certainty = "certain"
+			}
+			fmt.Fprintf(stdout, "%s\t%s\t%s\n", input, certainty, strings.Join(result.Candidates, " "))
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "sfid: %s\n", err)
+		return 1
+	}
+	return status
+}
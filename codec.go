@@ -0,0 +1,177 @@
+package salesforceid
+
+import "math/big"
+
+// digitValues maps a byte to its value in table or 0xff if it is not a Base62
+// digit.
+var digitValues = func() [256]byte {
+	var values [256]byte
+	for i := range values {
+		values[i] = 0xff
+	}
+	for i, c := range table {
+		values[c] = byte(i)
+	}
+	return values
+}()
+
+var (
+	identifierCodec       = Base62{Width: 8, Padded: true}
+	legacyIdentifierCodec = Base62{Width: 9, Padded: true}
+)
+
+// IdentifierCodec returns the codec for the 8 byte NumericIdentifier of
+// identifiers used by [Encode] and [Decode]. Changing the returned codec does
+// not affect them.
+func IdentifierCodec() Base62 {
+	return identifierCodec
+}
+
+// LegacyIdentifierCodec returns the codec for the 9 byte numeric identifier
+// used before one of the bytes was reserved. It can hold values up to 62^9
+// (13,537,086,546,263,552).
+func LegacyIdentifierCodec() Base62 {
+	return legacyIdentifierCodec
+}
+
+// Base62 encodes and decodes unsigned numbers using the digits of Salesforce
+// identifiers: `0-9`, `A-Z`, then `a-z`. The zero value encodes numbers of any
+// size with as few digits as possible.
+type Base62 struct {
+	// Width is the maximum number of digits. If Width is 0 the number of
+	// digits is unlimited.
+	Width int
+	// Padded requires exactly Width digits. Encode left pads with `0` and
+	// Decode rejects fewer digits. If Padded is false, Encode produces as
+	// few digits as possible and Decode accepts 1 to Width digits.
+	Padded bool
+}
+
+// Max returns the number of values the codec can hold, i.e., 62^Width. It
+// returns nil if Width is 0.
+func (c Base62) Max() *big.Int {
+	if c.Width <= 0 {
+		return nil
+	}
+	return new(big.Int).Exp(big.NewInt(base), big.NewInt(int64(c.Width)), nil)
+}
+
+// Encode converts u to Base62. This returns [ErrBase62Range] if u needs more
+// than Width digits.
+func (c Base62) Encode(u uint64) (string, error) {
+	b, err := c.AppendEncode(nil, u)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// AppendEncode appends the Base62 encoding of u to dst. This returns
+// [ErrBase62Range] if u needs more than Width digits.
+func (c Base62) AppendEncode(dst []byte, u uint64) ([]byte, error) {
+	// 62^11 is larger than the largest uint64
+	var buf [11]byte
+	i := len(buf)
+	for {
+		i--
+		buf[i] = table[u%base]
+		u /= base
+		if u == 0 {
+			break
+		}
+	}
+	digits := len(buf) - i
+	if c.Width > 0 && digits > c.Width {
+		return dst, ErrBase62Range
+	}
+	if c.Padded {
+		for ; digits < c.Width; digits++ {
+			dst = append(dst, '0')
+		}
+	}
+	return append(dst, buf[i:]...), nil
+}
+
+// Decode converts Base62 bytes to an unsigned integer. This returns
+// [ErrInvalidBase62] if src has the wrong number of digits or a byte that is
+// not a Base62 digit and [ErrBase62Range] if the value does not fit in a
+// uint64.
+func (c Base62) Decode(src []byte) (uint64, error) {
+	if err := c.checkLength(src); err != nil {
+		return 0, err
+	}
+	var v uint64
+	for _, b := range src {
+		d := digitValues[b]
+		if d == 0xff {
+			return 0, ErrInvalidBase62
+		}
+		if v > (^uint64(0)-uint64(d))/base {
+			return 0, ErrBase62Range
+		}
+		v = v*base + uint64(d)
+	}
+	return v, nil
+}
+
+// EncodeBig converts x to Base62. This returns [ErrBase62Range] if x is
+// negative or needs more than Width digits.
+func (c Base62) EncodeBig(x *big.Int) (string, error) {
+	if x.Sign() < 0 {
+		return "", ErrBase62Range
+	}
+	var digits []byte
+	v := new(big.Int).Set(x)
+	m := new(big.Int)
+	b := big.NewInt(base)
+	for {
+		v.DivMod(v, b, m)
+		digits = append(digits, table[m.Int64()])
+		if v.Sign() == 0 {
+			break
+		}
+	}
+	if c.Width > 0 && len(digits) > c.Width {
+		return "", ErrBase62Range
+	}
+	for c.Padded && len(digits) < c.Width {
+		digits = append(digits, '0')
+	}
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return string(digits), nil
+}
+
+// DecodeBig converts Base62 bytes to a big.Int. This returns
+// [ErrInvalidBase62] if src has the wrong number of digits or a byte that is
+// not a Base62 digit.
+func (c Base62) DecodeBig(src []byte) (*big.Int, error) {
+	if err := c.checkLength(src); err != nil {
+		return nil, err
+	}
+	v := new(big.Int)
+	b := big.NewInt(base)
+	d := new(big.Int)
+	for _, c := range src {
+		digit := digitValues[c]
+		if digit == 0xff {
+			return nil, ErrInvalidBase62
+		}
+		v.Mul(v, b)
+		v.Add(v, d.SetInt64(int64(digit)))
+	}
+	return v, nil
+}
+
+func (c Base62) checkLength(src []byte) error {
+	switch {
+	case len(src) == 0:
+		return ErrInvalidBase62
+	case c.Width > 0 && len(src) > c.Width:
+		return ErrInvalidBase62
+	case c.Padded && c.Width > 0 && len(src) != c.Width:
+		return ErrInvalidBase62
+	}
+	return nil
+}
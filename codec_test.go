@@ -0,0 +1,142 @@
+package salesforceid_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/sigmavirus24/salesforceid"
+)
+
+func TestBase62_Encode(t *testing.T) {
+	testCases := []struct {
+		name    string
+		codec   salesforceid.Base62
+		in      uint64
+		want    string
+		wantErr error
+	}{
+		{"identifier zero", salesforceid.IdentifierCodec(), 0, "00000000", nil},
+		{"identifier max", salesforceid.IdentifierCodec(), salesforceid.MaxIdentifierValue - 1, "zzzzzzzz", nil},
+		{"identifier overflow", salesforceid.IdentifierCodec(), salesforceid.MaxIdentifierValue, "", salesforceid.ErrBase62Range},
+		{"legacy identifier", salesforceid.LegacyIdentifierCodec(), salesforceid.MaxIdentifierValue, "100000000", nil},
+		{"legacy identifier max", salesforceid.LegacyIdentifierCodec(), 13_537_086_546_263_552 - 1, "zzzzzzzzz", nil},
+		{"legacy identifier overflow", salesforceid.LegacyIdentifierCodec(), 13_537_086_546_263_552, "", salesforceid.ErrBase62Range},
+		{"unpadded", salesforceid.Base62{Width: 8}, 1024, "GW", nil},
+		{"unpadded zero", salesforceid.Base62{Width: 8}, 0, "0", nil},
+		{"unbounded", salesforceid.Base62{}, ^uint64(0), "LygHa16AHYF", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.codec.Encode(tc.in)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected err %v but got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("Encode(%d) = %q, want %q", tc.in, got, tc.want)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			decoded, err := tc.codec.Decode([]byte(got))
+			if err != nil {
+				t.Fatalf("Decode(%q) error = %v", got, err)
+			}
+			if decoded != tc.in {
+				t.Errorf("Decode(%q) = %d, want %d", got, decoded, tc.in)
+			}
+		})
+	}
+}
+
+func TestBase62_Decode(t *testing.T) {
+	testCases := []struct {
+		name    string
+		codec   salesforceid.Base62
+		in      string
+		want    uint64
+		wantErr error
+	}{
+		{"identifier", salesforceid.IdentifierCodec(), "0000gy9o", 10241024, nil},
+		{"identifier too short", salesforceid.IdentifierCodec(), "000gy9o", 0, salesforceid.ErrInvalidBase62},
+		{"legacy identifier", salesforceid.LegacyIdentifierCodec(), "00000gy9o", 10241024, nil},
+		{"legacy identifier too long", salesforceid.LegacyIdentifierCodec(), "000000gy9o", 0, salesforceid.ErrInvalidBase62},
+		{"unpadded", salesforceid.Base62{Width: 8}, "gy9o", 10241024, nil},
+		{"unpadded too long", salesforceid.Base62{Width: 3}, "gy9o", 0, salesforceid.ErrInvalidBase62},
+		{"empty", salesforceid.Base62{}, "", 0, salesforceid.ErrInvalidBase62},
+		{"invalid digit", salesforceid.Base62{}, "gy-9o", 0, salesforceid.ErrInvalidBase62},
+		{"overflows uint64", salesforceid.Base62{}, "LygHa16AHYG", 0, salesforceid.ErrBase62Range},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.codec.Decode([]byte(tc.in))
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected err %v but got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("Decode(%q) = %d, want %d", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBase62_Big(t *testing.T) {
+	huge, _ := new(big.Int).SetString("340282366920938463463374607431768211456", 10) // 2^128
+	testCases := []struct {
+		name    string
+		codec   salesforceid.Base62
+		in      *big.Int
+		want    string
+		wantErr error
+	}{
+		{"zero", salesforceid.IdentifierCodec(), big.NewInt(0), "00000000", nil},
+		{"padded", salesforceid.LegacyIdentifierCodec(), big.NewInt(10241024), "00000gy9o", nil},
+		{"larger than uint64", salesforceid.Base62{}, huge, "7n42DGM5Tflk9n8mt7Fhc8", nil},
+		{"too wide", salesforceid.IdentifierCodec(), salesforceid.IdentifierCodec().Max(), "", salesforceid.ErrBase62Range},
+		{"negative", salesforceid.Base62{}, big.NewInt(-1), "", salesforceid.ErrBase62Range},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.codec.EncodeBig(tc.in)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected err %v but got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("EncodeBig(%s) = %q, want %q", tc.in, got, tc.want)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			decoded, err := tc.codec.DecodeBig([]byte(got))
+			if err != nil {
+				t.Fatalf("DecodeBig(%q) error = %v", got, err)
+			}
+			if decoded.Cmp(tc.in) != 0 {
+				t.Errorf("DecodeBig(%q) = %s, want %s", got, decoded, tc.in)
+			}
+		})
+	}
+}
+
+func TestBase62_Max(t *testing.T) {
+	if got := salesforceid.IdentifierCodec().Max(); got.Uint64() != salesforceid.MaxIdentifierValue {
+		t.Errorf("IdentifierCodec().Max() = %s, want %d", got, uint64(salesforceid.MaxIdentifierValue))
+	}
+	if got := (salesforceid.Base62{}).Max(); got != nil {
+		t.Errorf("Base62{}.Max() = %s, want nil", got)
+	}
+}
+
+func TestIdentifierCodec_copy(t *testing.T) {
+	codec := salesforceid.IdentifierCodec()
+	codec.Width = 9
+	if got := salesforceid.IdentifierCodec().Width; got != 8 {
+		t.Errorf("IdentifierCodec().Width = %d, want 8", got)
+	}
+	if _, err := salesforceid.New("00D000000000062"); err != nil {
+		t.Errorf("didn't expect an error but got %q", err)
+	}
+}
@@ -1,6 +1,9 @@
 package salesforceid
 
-import "bytes"
+import (
+	"bytes"
+	"fmt"
+)
 
 var (
 	table = []byte("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
@@ -11,74 +14,72 @@ var (
 )
 
 const (
-	zeroString = "00000000"
-	maxString  = "zzzzzzzz"
-	// oldMaxID  = 13_537_086_546_263_552 // == 62 ^ 9
+	maxString = "zzzzzzzz"
+	// oldMaxID  = 13_537_086_546_263_552 // == 62 ^ 9, see LegacyIdentifierCodec
 	MaxIdentifierValue = 218_340_105_584_896 // == 62 ^ 8
 	base               = 62
 )
 
 // Encode converts an unsigned integer to Base62 for use as a
-// NumericIdentifier. This can only handle 62^8. This returns
-// [ErrValueTooLarge] if u is larger than [MaxIdentifierValue].
+// NumericIdentifier using [IdentifierCodec]. This can only handle 62^8. This
+// returns [ErrValueTooLarge] if u is larger than [MaxIdentifierValue].
 func Encode(u uint64) (string, error) {
-	switch {
-	case u == 0:
-		return zeroString, nil
-	case u == MaxIdentifierValue:
+	if u == MaxIdentifierValue {
 		return maxString, nil
-	case u > MaxIdentifierValue:
-		return "", ErrValueTooLarge
 	}
-	ebytes := make([]byte, 0, 8)
-	for p := uint64(MaxIdentifierValue / base); p >= 1; p /= base {
-		m := u / p
-		ebytes = append(ebytes, table[m])
-		u -= m * p
+	encoded, err := identifierCodec.Encode(u)
+	if err != nil {
+		return "", ErrValueTooLarge
 	}
-	return string(ebytes), nil
+	return encoded, nil
 }
 
-// Decode converts bytes to an unsigned integer. This returns
-// [ErrInvalidNumericIdentifier] if the length of [src] is not 8 or if one of
-// the bytes is not a valid Base62 identifier.
+// Decode converts bytes to an unsigned integer using [IdentifierCodec]. This
+// returns a [*ParseError] wrapping [ErrInvalidNumericIdentifier] if the
+// length of [src] is not 8 or if one of the bytes is not a valid Base62
+// identifier.
 func Decode(src []byte) (uint64, error) {
-	var v uint64
-	var err error
-
-	if len(src) != 8 {
-		return v, ErrInvalidNumericIdentifier
-	}
-
-	m := uint64(1)
-_loop:
-	for i := 7; i >= 0; i-- {
-		c := src[i]
-		switch {
-		case c >= '0' && c <= '9':
-			v += uint64((c - '0')) * m
-		case (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z'):
-			v += uint64(bytes.IndexByte(table, c)) * m
-		default:
-			v = 0
-			err = ErrInvalidNumericIdentifier
-			break _loop
-		}
-		m *= 62
+	v, err := identifierCodec.Decode(src)
+	if err != nil {
+		return 0, numericError(src)
 	}
+	return v, nil
+}
 
-	return v, err
+// numericError describes why src could not be decoded by Decode.
+func numericError(src []byte) *ParseError {
+	err := &ParseError{Input: string(src), Offset: -1, Err: ErrInvalidNumericIdentifier}
+	if len(src) != identifierCodec.Width {
+		err.Reason = fmt.Sprintf("got %d bytes, want %d", len(src), identifierCodec.Width)
+		return err
+	}
+	for i, b := range src {
+		if digitValues[b] == 0xff {
+			err.Offset = i
+			err.Reason = fmt.Sprintf("%q at offset %d is not a base62 digit", b, i)
+			return err
+		}
+	}
+	err.Reason = "value is out of range"
+	return err
 }
 
 func computeEighteen(id []byte) []byte {
-	var chunkSum uint
-	newSFID := make([]byte, 15)
+	newSFID := make([]byte, 15, 18)
 	copy(newSFID, id[0:15])
+	suffix := checkSuffix(id[0:15])
+	return append(newSFID, suffix[:]...)
+}
+
+// checkSuffix computes the three check bytes for a 15 character identifier.
+func checkSuffix(id []byte) [3]byte {
+	var suffix [3]byte
+	var chunkSum uint
 
 	for i, b := range id {
 		if i%5 == 0 {
 			if i != 0 {
-				newSFID = append(newSFID, checkSeq[chunkSum])
+				suffix[i/5-1] = checkSeq[chunkSum]
 			}
 			chunkSum = 0
 		}
@@ -86,12 +87,20 @@ func computeEighteen(id []byte) []byte {
 			chunkSum += 1 << uint(i%5)
 		}
 	}
-	return append(newSFID, checkSeq[chunkSum])
+	suffix[2] = checkSeq[chunkSum]
+	return suffix
 }
 
-func normalize(id []byte) ([]byte, error) {
-	check := bytes.ToUpper(id[15:])
-	copy(id[15:18], check)
+// normalize fixes the case of the 18 character id in place to match its
+// check bytes. The returned error does not have its Input set.
+func normalize(id []byte) *ParseError {
+	actual := [3]byte(id[15:18])
+	check := id[15:18]
+	for i, b := range check {
+		if 'a' <= b && b <= 'z' {
+			check[i] = b - 32
+		}
+	}
 	for i, b := range id[:15] {
 		checkByte := check[i/5]
 		pow := 1 << uint(i%5)
@@ -111,7 +120,7 @@ func normalize(id []byte) ([]byte, error) {
 		// And use bitwise and to determine this byte's case
 		if checkVal&pow == pow {
 			if '0' <= b && b <= '9' {
-				return nil, ErrInvalidSFID
+				return checkError(id, i, actual[i/5])
 			}
 			if 'a' <= b && b <= 'z' {
 				// If this is lower case but should be upper, handle that
@@ -122,27 +131,128 @@ func normalize(id []byte) ([]byte, error) {
 			id[i] = b + 32
 		}
 	}
-	return id, nil
+	return nil
 }
 
-func prepareID(id string) ([]byte, error) {
-	var err error
-	if len(id) != 15 && len(id) != 18 {
-		return nil, ErrInvalidLengthSFID
-	}
-	idBytes := []byte(id)
-	if len(idBytes) == 18 {
-		idBytes, err = normalize(idBytes)
-		if err != nil {
-			return nil, err
+// checkError describes the digit at offset i of id which its check byte
+// says should be an upper case letter.
+func checkError(id []byte, i int, actual byte) *ParseError {
+	chunk := i / 5 * 5
+	expected := bytes.IndexByte(checkSeq, id[15+i/5])
+	if expected < 0 {
+		return &ParseError{
+			Offset: 15 + i/5,
+			Actual: actual,
+			Reason: fmt.Sprintf("%q at offset %d is not a check byte", actual, 15+i/5),
+			Err:    ErrInvalidSFID,
 		}
 	}
-	if len(id) == 15 {
-		idBytes = computeEighteen(idBytes)
+	for j, b := range id[chunk : chunk+5] {
+		if '0' <= b && b <= '9' {
+			expected &^= 1 << uint(j)
+		}
+	}
+	return &ParseError{
+		Offset:   i,
+		Expected: checkSeq[expected],
+		Actual:   actual,
+		Reason:   fmt.Sprintf("%q at offset %d must be a letter for check byte %q at offset %d", id[i], i, actual, 15+i/5),
+		Err:      ErrInvalidSFID,
+	}
+}
+
+// checkCharacters validates that every byte of the 15 or 18 character id is
+// Base62 and that the check bytes of an 18 character id are ones that
+// checkSuffix produces in either case. The returned error does not have its
+// Input set.
+func checkCharacters(id []byte) *ParseError {
+	for i, b := range id {
+		if digitValues[b] == 0xff {
+			return &ParseError{
+				Offset: i,
+				Actual: b,
+				Reason: fmt.Sprintf("%q at offset %d is not a base62 character", b, i),
+				Err:    ErrInvalidCharacter,
+			}
+		}
+		if i >= 15 && bytes.IndexByte(checkSeq, upper(b)) < 0 {
+			return &ParseError{
+				Offset: i,
+				Actual: b,
+				Reason: fmt.Sprintf("%q at offset %d is not a check byte", b, i),
+				Err:    ErrInvalidSFID,
+			}
+		}
+	}
+	return nil
+}
+
+// checkFields validates that the Reserved bytes of the 18 character id are
+// `0` for edition and that its NumericIdentifier decodes. The returned error
+// does not have its Input set.
+func checkFields(id []byte, edition IdentifierEdition) *ParseError {
+	start := 5
+	if edition == PostSummer23IdentifierEdition {
+		start = 6
+	}
+	for i, b := range id[start:7] {
+		if b != '0' {
+			return &ParseError{
+				Offset: start + i,
+				Reason: fmt.Sprintf("reserved byte %q at offset %d must be '0'", b, start+i),
+				Err:    ErrInvalidReserved,
+			}
+		}
+	}
+	if _, err := identifierCodec.Decode(id[7:15]); err != nil {
+		err := numericError(id[7:15])
+		if err.Offset >= 0 {
+			err.Offset += 7
+		}
+		return err
+	}
+	return nil
+}
+
+func prepareID(id string) ([]byte, error) {
+	idBytes := make([]byte, 18)
+	if err := prepareInto((*[18]byte)(idBytes), []byte(id)); err != nil {
+		return nil, err
 	}
 	return idBytes, nil
 }
 
+// prepareInto writes the 18 character form of id into dst without
+// allocating.
+func prepareInto(dst *[18]byte, id []byte) error {
+	if len(id) == 15 || len(id) == 18 {
+		if err := checkCharacters(id); err != nil {
+			err.Input = string(id)
+			return err
+		}
+	}
+	switch len(id) {
+	case 15:
+		copy(dst[:15], id)
+		suffix := checkSuffix(dst[:15])
+		copy(dst[15:], suffix[:])
+	case 18:
+		copy(dst[:], id)
+		if err := normalize(dst[:]); err != nil {
+			err.Input = string(id)
+			return err
+		}
+	default:
+		return &ParseError{
+			Input:  string(id),
+			Offset: -1,
+			Reason: fmt.Sprintf("got %d characters", len(id)),
+			Err:    ErrInvalidLengthSFID,
+		}
+	}
+	return nil
+}
+
 func addToID(numeric []byte, i uint64) (string, error) {
 	decoded, err := Decode(numeric)
 	if err != nil {
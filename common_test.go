@@ -1,6 +1,7 @@
 package salesforceid_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/sigmavirus24/salesforceid"
@@ -35,7 +36,7 @@ func TestEncode(t *testing.T) {
 			if tc.shouldErr && err == nil {
 				t.Errorf("expected error but got nil")
 			}
-			if tc.shouldErr && err != tc.expectedErr {
+			if tc.shouldErr && !errors.Is(err, tc.expectedErr) {
 				t.Errorf("expected err %q but got %q", tc.expectedErr, err)
 			}
 			if !tc.shouldErr && err != nil {
@@ -90,7 +91,7 @@ func TestDecode(t *testing.T) {
 			if tc.shouldErr && err == nil {
 				t.Errorf("expected error but got nil")
 			}
-			if tc.shouldErr && err != tc.expectedErr {
+			if tc.shouldErr && !errors.Is(err, tc.expectedErr) {
 				t.Errorf("expected err %q but got %q", tc.expectedErr, err)
 			}
 			if !tc.shouldErr && err != nil {
@@ -120,3 +121,27 @@ func BenchmarkDecode(b *testing.B) {
 		})
 	}
 }
+
+func TestDecode_parseError(t *testing.T) {
+	testCases := []struct {
+		in         string
+		wantOffset int
+		wantReason string
+	}{
+		{"0000000GW", -1, "got 9 bytes, want 8"},
+		{"0000_000", 4, "'_' at offset 4 is not a base62 digit"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			_, err := salesforceid.Decode([]byte(tc.in))
+			var got *salesforceid.ParseError
+			if !errors.As(err, &got) {
+				t.Fatalf("expected a ParseError but got %v", err)
+			}
+			if got.Input != tc.in || got.Offset != tc.wantOffset || got.Reason != tc.wantReason {
+				t.Errorf("Decode(%q) error = %+v, want offset %d and reason %q", tc.in, *got, tc.wantOffset, tc.wantReason)
+			}
+		})
+	}
+}
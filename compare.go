@@ -0,0 +1,53 @@
+package salesforceid
+
+import "bytes"
+
+// Compare returns -1 if a sorts before b, 0 if they are the same identifier,
+// and +1 if a sorts after b. It can be used with [slices.SortFunc]. A nil
+// SalesforceID sorts before all other identifiers.
+//
+// Identifiers are ordered the way Salesforce orders the Id field: by the
+// bytes of the 15 character identifier. Base62 digits are ordered `0-9`,
+// `A-Z`, then `a-z` which matches their byte order, so identifiers with the
+// same KeyPrefix and PodIdentifier are ordered by their NumericIdentifier.
+// Identifiers with different key prefixes are ordered by KeyPrefix and then
+// PodIdentifier which is stable but has no meaning to Salesforce, as a SOQL
+// query only ever compares identifiers of one object. Edition does not affect
+// the order since both editions share the same bytes.
+func Compare(a, b *SalesforceID) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	return bytes.Compare(a.fifteen(), b.fifteen())
+}
+
+// fifteen returns the 15 character identifier or nil for a nil or zero
+// value SalesforceID.
+func (s *SalesforceID) fifteen() []byte {
+	if s == nil || len(s.id) < 15 {
+		return nil
+	}
+	return s.id[:15]
+}
+
+// Compare returns -1 if s sorts before o, 0 if they are the same identifier,
+// and +1 if s sorts after o. See [Compare] for how identifiers are ordered.
+func (s *SalesforceID) Compare(o *SalesforceID) int {
+	return Compare(s, o)
+}
+
+// Equal reports whether s and o are the same identifier regardless of the
+// format or casing they were parsed from.
+func (s *SalesforceID) Equal(o *SalesforceID) bool {
+	return Compare(s, o) == 0
+}
+
+// Less reports whether s sorts before o.
+func (s *SalesforceID) Less(o *SalesforceID) bool {
+	return Compare(s, o) < 0
+}
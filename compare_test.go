@@ -0,0 +1,86 @@
+package salesforceid_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sigmavirus24/salesforceid"
+)
+
+func TestCompare(t *testing.T) {
+	testCases := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{"same identifier", "001000000000062", "001000000000062AAA", 0},
+		{"casing corrected by check bytes", "00D000000000062", "00d000000000062eaa", 0},
+		{"digits before upper case", "001000000000009", "00100000000000A", -1},
+		{"upper case before lower case", "00100000000000Z", "00100000000000a", -1},
+		{"numeric identifier", "001000000000100", "00100000000000z", 1},
+		{"key prefix", "003000000000000", "0010000zzzzzzzz", 1},
+		{"pod identifier", "00130000000000z", "001400000000000", -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := salesforceid.New(tc.a)
+			if err != nil {
+				t.Fatalf("New(%q) error = %v", tc.a, err)
+			}
+			b, err := salesforceid.New(tc.b)
+			if err != nil {
+				t.Fatalf("New(%q) error = %v", tc.b, err)
+			}
+			if got := salesforceid.Compare(a, b); got != tc.want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", a, b, got, tc.want)
+			}
+			if got := b.Compare(a); got != -tc.want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", b, a, got, -tc.want)
+			}
+			if got := a.Equal(b); got != (tc.want == 0) {
+				t.Errorf("Equal(%s, %s) = %v, want %v", a, b, got, tc.want == 0)
+			}
+			if got := a.Less(b); got != (tc.want < 0) {
+				t.Errorf("Less(%s, %s) = %v, want %v", a, b, got, tc.want < 0)
+			}
+		})
+	}
+}
+
+func TestCompare_editions(t *testing.T) {
+	pre, _ := salesforceid.Parse("001300000000062", salesforceid.PreSummer23IdentifierEdition)
+	post, _ := salesforceid.Parse("001300000000062", salesforceid.PostSummer23IdentifierEdition)
+	if !pre.Equal(post) {
+		t.Errorf("expected %s to equal %s across editions", pre, post)
+	}
+}
+
+func TestCompare_nil(t *testing.T) {
+	id, _ := salesforceid.New("001000000000062")
+	if got := salesforceid.Compare(nil, id); got != -1 {
+		t.Errorf("Compare(nil, %s) = %d, want -1", id, got)
+	}
+	if got := salesforceid.Compare(id, nil); got != 1 {
+		t.Errorf("Compare(%s, nil) = %d, want 1", id, got)
+	}
+	if got := salesforceid.Compare(nil, nil); got != 0 {
+		t.Errorf("Compare(nil, nil) = %d, want 0", got)
+	}
+}
+
+func TestCompare_sortFunc(t *testing.T) {
+	var ids []*salesforceid.SalesforceID
+	for _, s := range []string{"00100000000000a", "001000000000010", "00100000000000Z", "001000000000009"} {
+		id, _ := salesforceid.New(s)
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, salesforceid.Compare)
+	want := []string{"001000000000009", "00100000000000Z", "00100000000000a", "001000000000010"}
+	for i, id := range ids {
+		if got := id.Format(salesforceid.FifteenCharacterFormat); got != want[i] {
+			t.Errorf("sorted[%d] = %s, want %s", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package salesforceid
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+// DefaultCSVDetectRows is the number of records [NormalizeCSV] samples to
+// detect identifier columns when [CSVOptions.DetectRows] is 0.
+const DefaultCSVDetectRows = 100
+
+// CSVOptions configures [NormalizeCSV].
+type CSVOptions struct {
+	// Header indicates the first record is a header. It is written
+	// unchanged.
+	Header bool
+	// Columns selects the columns containing identifiers. With a Header
+	// these are column names, otherwise they are 1-based column numbers.
+	// If Columns is empty, identifier columns are detected.
+	Columns []string
+	// DetectRows is the number of records sampled to detect identifier
+	// columns. A column is detected if it has at least one value in the
+	// sample and every value in the sample is a valid identifier. If
+	// DetectRows is 0, [DefaultCSVDetectRows] is used.
+	DetectRows int
+	// Comma is the field delimiter. If Comma is 0, `,` is used.
+	Comma rune
+	// Edition is used to parse identifiers. If Edition is 0, it is detected
+	// as with [New].
+	Edition IdentifierEdition
+	// OnInvalid is called for every cell in an identifier column that is
+	// not a valid identifier. The cell is written unchanged. If OnInvalid
+	// is nil, NormalizeCSV stops and returns the [*CSVCellError] instead.
+	OnInvalid func(*CSVCellError)
+}
+
+// NormalizeCSV copies CSV records from src to dst replacing the identifiers
+// in the selected or detected columns with their 18 character form. Empty
+// cells are left empty. Records are streamed so memory use does not grow with
+// the size of src, apart from the records sampled to detect columns.
+func NormalizeCSV(dst io.Writer, src io.Reader, opts CSVOptions) error {
+	r := csv.NewReader(src)
+	r.FieldsPerRecord = -1
+	if opts.Comma != 0 {
+		r.Comma = opts.Comma
+	}
+	w := csv.NewWriter(dst)
+	w.Comma = r.Comma
+	edition := opts.Edition
+	if edition == 0 {
+		edition = AutoIdentifierEdition
+	}
+	detectRows := opts.DetectRows
+	if detectRows == 0 {
+		detectRows = DefaultCSVDetectRows
+	}
+
+	row := 0
+	var header []string
+	if opts.Header {
+		record, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		header = slices.Clone(record)
+		row++
+		if err := w.Write(header); err != nil {
+			return err
+		}
+	}
+
+	var sample [][]string
+	columns, err := csvColumns(opts.Columns, header)
+	if err != nil {
+		return err
+	}
+	if len(opts.Columns) == 0 {
+		for len(sample) < detectRows {
+			record, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			sample = append(sample, record)
+		}
+		columns = detectCSVColumns(sample, edition)
+	}
+
+	normalize := func(record []string) error {
+		row++
+		for _, column := range columns {
+			if column >= len(record) || record[column] == "" {
+				continue
+			}
+			id, err := Parse(record[column], edition)
+			if err != nil {
+				cellErr := &CSVCellError{Row: row, Column: column + 1, Value: record[column], Err: err}
+				if column < len(header) {
+					cellErr.ColumnName = header[column]
+				}
+				if opts.OnInvalid == nil {
+					return cellErr
+				}
+				opts.OnInvalid(cellErr)
+				continue
+			}
+			record[column] = id.String()
+		}
+		return w.Write(record)
+	}
+
+	for _, record := range sample {
+		if err := normalize(record); err != nil {
+			return err
+		}
+	}
+	sample = nil
+	r.ReuseRecord = true
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := normalize(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// csvColumns converts column names or 1-based column numbers to 0-based
+// column indexes.
+func csvColumns(names, header []string) ([]int, error) {
+	columns := make([]int, 0, len(names))
+	for _, name := range names {
+		if header != nil {
+			i := slices.Index(header, name)
+			if i < 0 {
+				return nil, fmt.Errorf("%w: %q", ErrUnknownCSVColumn, name)
+			}
+			columns = append(columns, i)
+			continue
+		}
+		i, err := strconv.Atoi(name)
+		if err != nil || i < 1 {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownCSVColumn, name)
+		}
+		columns = append(columns, i-1)
+	}
+	return columns, nil
+}
+
+// detectCSVColumns returns the indexes of the columns that have at least one
+// value in sample and only contain valid identifiers.
+func detectCSVColumns(sample [][]string, edition IdentifierEdition) []int {
+	var columns []int
+	for column := 0; ; column++ {
+		present, found, valid := false, false, true
+		for _, record := range sample {
+			if column >= len(record) {
+				continue
+			}
+			present = true
+			if record[column] == "" {
+				continue
+			}
+			found = true
+			if _, err := Parse(record[column], edition); err != nil {
+				valid = false
+				break
+			}
+		}
+		if !present {
+			return columns
+		}
+		if found && valid {
+			columns = append(columns, column)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package salesforceid_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sigmavirus24/salesforceid"
+)
+
+func TestNormalizeCSV(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		opts        salesforceid.CSVOptions
+		want        string
+		wantInvalid []string
+		wantErr     error
+	}{
+		{
+			name:  "selected columns by name",
+			input: "Id,Name,AccountId\n00D000000000062,Acme,001000000000000\n00d000000000062eaa,Initech,\n",
+			opts:  salesforceid.CSVOptions{Header: true, Columns: []string{"Id", "AccountId"}},
+			want:  "Id,Name,AccountId\n00D000000000062EAA,Acme,001000000000000AAA\n00D000000000062EAA,Initech,\n",
+		},
+		{
+			name:  "selected columns by number",
+			input: "00D000000000062,Acme,001000000000000\n",
+			opts:  salesforceid.CSVOptions{Columns: []string{"3"}},
+			want:  "00D000000000062,Acme,001000000000000AAA\n",
+		},
+		{
+			name:  "detected columns",
+			input: "Id,Name,AccountId,Code\n00D000000000062,Acme,,ABC\n00D000000000063,Initech,001000000000000,ABCDEFGHIJKLMNO\n",
+			opts:  salesforceid.CSVOptions{Header: true, DetectRows: 1},
+			want:  "Id,Name,AccountId,Code\n00D000000000062EAA,Acme,,ABC\n00D000000000063EAA,Initech,001000000000000,ABCDEFGHIJKLMNO\n",
+		},
+		{
+			name:  "detected columns skip columns with invalid samples",
+			input: "Id,Name,Code\n00D000000000062,Acme,ABCDEFGHIJKLMNO\n00D000000000063,Initech,ABC\n",
+			opts:  salesforceid.CSVOptions{Header: true},
+			want:  "Id,Name,Code\n00D000000000062EAA,Acme,ABCDEFGHIJKLMNO\n00D000000000063EAA,Initech,ABC\n",
+		},
+		{
+			name:  "post summer 23 identifiers",
+			input: "Id\n0011Ab000000062\n",
+			opts:  salesforceid.CSVOptions{Header: true, Columns: []string{"Id"}},
+			want:  "Id\n0011Ab000000062QAA\n",
+		},
+		{
+			name:        "reports invalid cells",
+			input:       "Id;Name\n00D000000000062;Acme\n00D00000000006;Initech\n001000000000062EAA;Globex\n",
+			opts:        salesforceid.CSVOptions{Header: true, Columns: []string{"Id"}, Comma: ';'},
+			want:        "Id;Name\n00D000000000062EAA;Acme\n00D00000000006;Initech\n001000000000062EAA;Globex\n",
+			wantInvalid: []string{"row 3, column 1 (Id)", "row 4, column 1 (Id)"},
+		},
+		{
+			name:    "stops at invalid cells without OnInvalid",
+			input:   "00D00000000006,Initech\n",
+			opts:    salesforceid.CSVOptions{Columns: []string{"1"}},
+			wantErr: salesforceid.ErrInvalidLengthSFID,
+		},
+		{
+			name:    "unknown column",
+			input:   "Id,Name\n",
+			opts:    salesforceid.CSVOptions{Header: true, Columns: []string{"AccountId"}},
+			wantErr: salesforceid.ErrUnknownCSVColumn,
+		},
+		{
+			name:  "empty input",
+			input: "",
+			opts:  salesforceid.CSVOptions{Header: true},
+			want:  "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got bytes.Buffer
+			var invalid []string
+			if tc.wantErr == nil {
+				tc.opts.OnInvalid = func(err *salesforceid.CSVCellError) {
+					invalid = append(invalid, err.Error()[:strings.Index(err.Error(), ":")])
+				}
+			}
+			err := salesforceid.NormalizeCSV(&got, strings.NewReader(tc.input), tc.opts)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Errorf("expected err %q but got %q", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("didn't expect an error but got %q", err)
+			}
+			if diff := cmp.Diff(got.String(), tc.want); diff != "" {
+				t.Errorf("NormalizeCSV() = %q, want %q, diff = %s", got.String(), tc.want, diff)
+			}
+			if diff := cmp.Diff(invalid, tc.wantInvalid); diff != "" {
+				t.Errorf("invalid cells = %v, want %v, diff = %s", invalid, tc.wantInvalid, diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeCSV_cellError(t *testing.T) {
+	err := salesforceid.NormalizeCSV(&bytes.Buffer{}, strings.NewReader("Id\n00D000000000062\n00D00000000006\n"), salesforceid.CSVOptions{Header: true, Columns: []string{"Id"}})
+	var cellErr *salesforceid.CSVCellError
+	if !errors.As(err, &cellErr) {
+		t.Fatalf("expected a CSVCellError but got %v", err)
+	}
+	want := salesforceid.CSVCellError{Row: 3, Column: 1, ColumnName: "Id", Value: "00D00000000006", Err: cellErr.Err}
+	if *cellErr != want {
+		t.Errorf("NormalizeCSV() error = %+v, want %+v", *cellErr, want)
+	}
+	if !errors.Is(err, salesforceid.ErrInvalidLengthSFID) {
+		t.Errorf("expected err %q but got %q", salesforceid.ErrInvalidLengthSFID, err)
+	}
+}
+
+func BenchmarkNormalizeCSV(b *testing.B) {
+	var input strings.Builder
+	input.WriteString("Id,Name,AccountId\n")
+	for range 1000 {
+		input.WriteString("00d000000000062eaa,Acme,001000000000000\n")
+	}
+	opts := salesforceid.CSVOptions{Header: true}
+	for i := 0; i < b.N; i++ {
+		_ = salesforceid.NormalizeCSV(&bytes.Buffer{}, strings.NewReader(input.String()), opts)
+	}
+}
@@ -0,0 +1,57 @@
+package salesforceid
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// describeGlobalResponse is the subset of the describeGlobal REST resource
+// that is needed to map key prefixes to sObject names.
+// See also:
+// * https://developer.salesforce.com/docs/atlas.en-us.api_rest.meta/api_rest/resources_describeGlobal.htm
+type describeGlobalResponse struct {
+	SObjects []struct {
+		KeyPrefix *string `json:"keyPrefix"`
+		Name      string  `json:"name"`
+	} `json:"sobjects"`
+}
+
+// LoadDescribeGlobal creates a KeyPrefixRegistry for a single org from a
+// saved describeGlobal response. The registry starts with the standard
+// objects and then adds every sObject in the response that has a key prefix.
+func LoadDescribeGlobal(rd io.Reader) (*KeyPrefixRegistry, error) {
+	r := NewStandardKeyPrefixRegistry()
+	if err := r.LoadDescribeGlobal(rd); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// LoadDescribeGlobal adds every sObject with a key prefix in a saved
+// describeGlobal response to the registry. sObjects without a key prefix
+// (e.g., most `__Share` and `__Feed` objects) are skipped. Nothing is added
+// if the response cannot be decoded or contains an invalid key prefix.
+func (r *KeyPrefixRegistry) LoadDescribeGlobal(rd io.Reader) error {
+	var resp describeGlobalResponse
+	if err := json.NewDecoder(rd).Decode(&resp); err != nil {
+		return fmt.Errorf("decoding describeGlobal response: %w", err)
+	}
+	prefixes := make(map[string]string, len(resp.SObjects))
+	for _, sobject := range resp.SObjects {
+		if sobject.KeyPrefix == nil || *sobject.KeyPrefix == "" {
+			continue
+		}
+		if len(*sobject.KeyPrefix) != 3 {
+			return fmt.Errorf("%w: %q for %s", ErrInvalidKeyPrefix, *sobject.KeyPrefix, sobject.Name)
+		}
+		prefixes[*sobject.KeyPrefix] = sobject.Name
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for prefix, name := range prefixes {
+		r.prefixes[prefix] = name
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package salesforceid_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sigmavirus24/salesforceid"
+)
+
+const describeGlobal = `{
+  "encoding": "UTF-8",
+  "maxBatchSize": 200,
+  "sobjects": [
+    {"keyPrefix": "001", "name": "Account", "custom": false},
+    {"keyPrefix": "a0X", "name": "Invoice__c", "custom": true},
+    {"keyPrefix": "a0Y", "name": "Invoice_Line__c", "custom": true},
+    {"keyPrefix": null, "name": "Invoice__Share", "custom": true}
+  ]
+}`
+
+func TestLoadDescribeGlobal(t *testing.T) {
+	registry, err := salesforceid.LoadDescribeGlobal(strings.NewReader(describeGlobal))
+	if err != nil {
+		t.Fatalf("LoadDescribeGlobal() error = %v", err)
+	}
+	testCases := []struct {
+		sfid    string
+		want    string
+		wantErr bool
+	}{
+		{"001000000000000AAA", "Account", false},
+		{"003D0000001aH2AIAU", "Contact", false},
+		{"a0X000000000062", "Invoice__c", false},
+		{"a0Y000000000062", "Invoice_Line__c", false},
+		{"a0Z000000000062", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.sfid, func(t *testing.T) {
+			id, err := salesforceid.New(tc.sfid)
+			if err != nil {
+				t.Fatalf("New(%q) error = %v", tc.sfid, err)
+			}
+			got, err := registry.ObjectType(id)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ObjectType() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ObjectType() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKeyPrefixRegistry_LoadDescribeGlobal(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"not json", "<sobjects/>", nil},
+		{"invalid key prefix", `{"sobjects": [{"keyPrefix": "a0", "name": "Short__c"}]}`, salesforceid.ErrInvalidKeyPrefix},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := salesforceid.NewKeyPrefixRegistry()
+			err := r.LoadDescribeGlobal(strings.NewReader(tc.input))
+			if err == nil {
+				t.Fatalf("expected error but got nil")
+			}
+			if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+				t.Errorf("expected err %q but got %q", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -29,3 +29,152 @@ var ErrInvalidAddition = fmt.Errorf("addition would overflow maximum value: %d",
 // ErrInvalidSubtraction is returned when the amount to subtract from the
 // identifier is greater than the decoded value of the NumericIdentifier
 var ErrInvalidSubtraction = errors.New("subtraction would result in a negative identifier")
+
+// ErrInvalidKeyPrefix is returned when registering a key prefix that is not
+// 3 bytes long
+var ErrInvalidKeyPrefix = errors.New("key prefixes should be 3 characters")
+
+// ErrUnknownKeyPrefix is matched by [UnknownKeyPrefixError] with errors.Is
+var ErrUnknownKeyPrefix = errors.New("unknown key prefix")
+
+// UnknownKeyPrefixError is returned when looking up a key prefix that has not
+// been registered
+type UnknownKeyPrefixError struct {
+	Prefix string
+}
+
+func (e *UnknownKeyPrefixError) Error() string {
+	return fmt.Sprintf("%s: %q", ErrUnknownKeyPrefix, e.Prefix)
+}
+
+func (e *UnknownKeyPrefixError) Unwrap() error {
+	return ErrUnknownKeyPrefix
+}
+
+// ErrInvalidPodIdentifier is returned when registering a pod identifier that
+// is not 2 or 3 bytes long
+var ErrInvalidPodIdentifier = errors.New("pod identifiers should be 2 or 3 characters")
+
+// ErrUnknownPodIdentifier is matched by [UnknownPodIdentifierError] with
+// errors.Is
+var ErrUnknownPodIdentifier = errors.New("unknown pod identifier")
+
+// UnknownPodIdentifierError is returned when looking up a pod identifier that
+// has not been registered
+type UnknownPodIdentifierError struct {
+	PodIdentifier string
+}
+
+func (e *UnknownPodIdentifierError) Error() string {
+	return fmt.Sprintf("%s: %q", ErrUnknownPodIdentifier, e.PodIdentifier)
+}
+
+func (e *UnknownPodIdentifierError) Unwrap() error {
+	return ErrUnknownPodIdentifier
+}
+
+// ErrScanNull is returned when scanning a NULL value into a SalesforceID. Use
+// NullSalesforceID for columns that may be NULL
+var ErrScanNull = errors.New("cannot scan NULL into SalesforceID")
+
+// ErrUnsupportedScanType is returned when scanning a value that is neither a
+// string nor bytes into a SalesforceID
+var ErrUnsupportedScanType = errors.New("unsupported type for SalesforceID")
+
+// ErrInvalidChunkSize is returned when chunking a range into chunks of 0
+// identifiers
+var ErrInvalidChunkSize = errors.New("chunk size must be greater than 0")
+
+// ErrInvalidRange is returned when the start of a range is after its end or
+// either is missing
+var ErrInvalidRange = errors.New("range start must not be after its end")
+
+// ErrMismatchedRange is returned when the identifiers of a range do not share
+// the same key prefix, pod identifier, and reserved bytes
+var ErrMismatchedRange = errors.New("range identifiers must share a key prefix, pod identifier, and reserved bytes")
+
+// ErrMixedKeyPrefix is returned when building a SOQL condition from
+// identifiers that do not all share the same key prefix
+var ErrMixedKeyPrefix = errors.New("identifiers must share a key prefix")
+
+// ErrInvalidClauseLength is returned when the maximum length of a SOQL
+// condition is too short to fit a single identifier
+var ErrInvalidClauseLength = errors.New("maximum clause length is too short for an identifier")
+
+// ErrUnknownCSVColumn is returned when a selected CSV column is not in the
+// header or is not a valid column number
+var ErrUnknownCSVColumn = errors.New("unknown CSV column")
+
+// CSVCellError describes a CSV cell in an identifier column that does not
+// contain a valid identifier. Row and Column are 1-based and Row counts the
+// header.
+type CSVCellError struct {
+	Row        int
+	Column     int
+	ColumnName string
+	Value      string
+	Err        error
+}
+
+func (e *CSVCellError) Error() string {
+	if e.ColumnName != "" {
+		return fmt.Sprintf("row %d, column %d (%s): %q: %s", e.Row, e.Column, e.ColumnName, e.Value, e.Err)
+	}
+	return fmt.Sprintf("row %d, column %d: %q: %s", e.Row, e.Column, e.Value, e.Err)
+}
+
+func (e *CSVCellError) Unwrap() error {
+	return e.Err
+}
+
+// ErrInvalidBinary is returned when a binary encoded identifier is the wrong
+// length or holds values that are out of range
+var ErrInvalidBinary = errors.New("invalid binary encoding of identifier")
+
+// ErrInvalidBase62 is returned when decoding Base62 bytes with the wrong
+// number of digits or bytes that are not Base62 digits
+var ErrInvalidBase62 = errors.New("invalid base62 digits")
+
+// ErrBase62Range is returned when a value is negative or does not fit in the
+// number of digits of a Base62 codec or in a uint64
+var ErrBase62Range = errors.New("value is out of range for base62 codec")
+
+// ErrInvalidCharacter is returned when an identifier contains a byte that is
+// not a Base62 character
+var ErrInvalidCharacter = errors.New("identifiers may only contain base62 characters")
+
+// ErrInvalidReserved is returned when the Reserved bytes of an identifier are
+// not `0`
+var ErrInvalidReserved = errors.New("reserved bytes must be 0")
+
+// ParseError describes an identifier that could not be parsed. It wraps one
+// of [ErrInvalidLengthSFID], [ErrInvalidCharacter], [ErrInvalidSFID],
+// [ErrInvalidReserved], or [ErrInvalidNumericIdentifier] so it can be matched
+// with errors.Is.
+type ParseError struct {
+	// Input is the identifier being parsed
+	Input string
+	// Offset is the 0-based byte offset in Input of the first byte that
+	// could not be parsed or -1 if the error is not caused by a single byte
+	Offset int
+	// Expected is the check byte that would match the identifier and Actual
+	// is the check byte in Input when the check bytes do not match,
+	// otherwise both are 0
+	Expected byte
+	Actual   byte
+	// Reason describes the problem with Input
+	Reason string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parsing %q: %s: %s", e.Input, e.Err, e.Reason)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ErrUnrepairable is returned when an identifier damaged by a spreadsheet
+// could not be reconstructed
+var ErrUnrepairable = errors.New("identifier could not be repaired")
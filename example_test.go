@@ -107,3 +107,41 @@ func ExampleEncode_second() {
 	fmt.Printf("%s == %s", string(id.NumericIdentifier), encoded)
 	// Output: 00000062 == 00000062
 }
+
+func ExampleParseAuto() {
+	id, confidence, err := sfid.ParseAuto("0011Ab000000062")
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Printf("%s %s %v", id.PodIdentifier, id.Reserved, confidence == sfid.HighConfidence)
+	// Output: 1Ab 0 true
+}
+
+func ExampleChunk() {
+	start, _ := sfid.New("001000000000000")
+	end, _ := sfid.New("00100000000FjXr")
+
+	for r, err := range sfid.Chunk(start, end, 250000) {
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("SELECT Id FROM Account WHERE Id >= '%s' AND Id <= '%s'\n", r.Start, r.End)
+	}
+	// Output:
+	// SELECT Id FROM Account WHERE Id >= '001000000000000AAA' AND Id <= '00100000000132FAAQ'
+	// SELECT Id FROM Account WHERE Id >= '00100000000132GAAQ' AND Id <= '00100000000264VAAQ'
+	// SELECT Id FROM Account WHERE Id >= '00100000000264WAAQ' AND Id <= '00100000000396lAAA'
+	// SELECT Id FROM Account WHERE Id >= '00100000000396mAAA' AND Id <= '001000000004C91AAE'
+	// SELECT Id FROM Account WHERE Id >= '001000000004C92AAE' AND Id <= '001000000005FBHAA2'
+	// SELECT Id FROM Account WHERE Id >= '001000000005FBIAA2' AND Id <= '001000000006IDXAA2'
+	// SELECT Id FROM Account WHERE Id >= '001000000006IDYAA2' AND Id <= '001000000007LFnAAM'
+	// SELECT Id FROM Account WHERE Id >= '001000000007LFoAAM' AND Id <= '001000000008OI3AAM'
+	// SELECT Id FROM Account WHERE Id >= '001000000008OI4AAM' AND Id <= '001000000009RKJAA2'
+	// SELECT Id FROM Account WHERE Id >= '001000000009RKKAA2' AND Id <= '00100000000AUMZAA4'
+	// SELECT Id FROM Account WHERE Id >= '00100000000AUMaAAO' AND Id <= '00100000000BXOpAAO'
+	// SELECT Id FROM Account WHERE Id >= '00100000000BXOqAAO' AND Id <= '00100000000CaR5AAK'
+	// SELECT Id FROM Account WHERE Id >= '00100000000CaR6AAK' AND Id <= '00100000000DdTLAA0'
+	// SELECT Id FROM Account WHERE Id >= '00100000000DdTMAA0' AND Id <= '00100000000EgVbAAK'
+	// SELECT Id FROM Account WHERE Id >= '00100000000EgVcAAK' AND Id <= '00100000000FjXrAAK'
+}
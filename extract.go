@@ -0,0 +1,128 @@
+package salesforceid
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"iter"
+	"strings"
+)
+
+// Match is an identifier found by [Extract] or [ExtractReader].
+type Match struct {
+	Offset int64  // Offset is the byte offset of the identifier in the input
+	Text   string // Text is the identifier as it appeared in the input
+	ID     *SalesforceID
+}
+
+// Extract finds the identifiers in text. See [ExtractReader] for which
+// candidates are considered identifiers.
+func Extract(text string) []Match {
+	var matches []Match
+	for m := range ExtractReader(strings.NewReader(text)) {
+		matches = append(matches, m)
+	}
+	return matches
+}
+
+// ExtractReader finds the identifiers in r. Candidates are runs of exactly
+// 15 or 18 base62 bytes surrounded by other bytes or the ends of the input.
+// To avoid false positives, a candidate is rejected if:
+//
+//   - it is all digits or has no digits
+//   - its seventh byte, which is reserved, is not `0`
+//   - it has 18 bytes and its check suffix does not match the casing of the
+//     first 15 bytes, unless all of its letters share a case as happens when
+//     a system treats identifiers as case-insensitive
+//   - it has 15 bytes and only contains lower case hexadecimal digits, which
+//     is more likely part of a hash than an identifier
+//
+// The edition of each identifier is detected as with [ParseAuto]. Iteration
+// stops after yielding an error if reading from r fails.
+func ExtractReader(r io.Reader) iter.Seq2[Match, error] {
+	return func(yield func(Match, error) bool) {
+		br := bufio.NewReader(r)
+		// A token longer than 18 bytes can never match so only 19 bytes are
+		// kept to know a token was too long.
+		var token [19]byte
+		n := 0
+		var offset, start int64
+		for {
+			b, err := br.ReadByte()
+			if err != nil && err != io.EOF {
+				yield(Match{}, err)
+				return
+			}
+			if err == nil && isBase62(b) {
+				if n == 0 {
+					start = offset
+				}
+				if n < len(token) {
+					token[n] = b
+					n++
+				}
+				offset++
+				continue
+			}
+			if id := candidate(token[:n]); id != nil {
+				if !yield(Match{Offset: start, Text: string(token[:n]), ID: id}, nil) {
+					return
+				}
+			}
+			if err == io.EOF {
+				return
+			}
+			n = 0
+			offset++
+		}
+	}
+}
+
+func isBase62(b byte) bool {
+	return ('0' <= b && b <= '9') || ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z')
+}
+
+// candidate returns the SalesforceID for token if it looks like an
+// identifier.
+func candidate(token []byte) *SalesforceID {
+	if len(token) != 15 && len(token) != 18 || token[6] != '0' {
+		return nil
+	}
+	var digits, lower, upper, hex int
+	for _, b := range token[:15] {
+		switch {
+		case '0' <= b && b <= '9':
+			digits++
+			hex++
+		case 'a' <= b && b <= 'z':
+			lower++
+			if b <= 'f' {
+				hex++
+			}
+		default:
+			upper++
+		}
+	}
+	if digits == 0 || digits == 15 {
+		return nil
+	}
+	if len(token) == 15 && hex == 15 {
+		return nil
+	}
+	if len(token) == 18 {
+		for _, b := range token[15:] {
+			if bytes.IndexByte(checkSeq, b&^0x20) < 0 && bytes.IndexByte(checkSeq, b) < 0 {
+				return nil
+			}
+		}
+		singleCase := lower == 0 || upper == 0
+		if !singleCase && !bytes.Equal(computeEighteen(token[:15])[15:], token[15:]) {
+			return nil
+		}
+	}
+	id, err := Parse(string(token), AutoIdentifierEdition)
+	if err != nil {
+		return nil
+	}
+	return id
+}
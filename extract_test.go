@@ -0,0 +1,115 @@
+package salesforceid_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sigmavirus24/salesforceid"
+)
+
+type extracted struct {
+	Offset int64
+	Text   string
+	ID     string
+}
+
+func TestExtract(t *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+		want []extracted
+	}{
+		{
+			name: "15 and 18 character identifiers",
+			text: "Account 001300000000abC was merged into 00130000000abCdAAI.",
+			want: []extracted{
+				{8, "001300000000abC", "001300000000abCAAQ"},
+				{40, "00130000000abCdAAI", "00130000000abCdAAI"},
+			},
+		},
+		{
+			name: "surrounded by punctuation",
+			text: `{"Id":"00D000000000062EAA"},(0010000000000xY)`,
+			want: []extracted{
+				{7, "00D000000000062EAA", "00D000000000062EAA"},
+				{29, "0010000000000xY", "0010000000000xYAAQ"},
+			},
+		},
+		{
+			name: "case-insensitive 18 character identifier",
+			text: "org id: 00d000000000062eaa",
+			want: []extracted{{8, "00d000000000062eaa", "00D000000000062EAA"}},
+		},
+		{
+			name: "multi-byte characters are boundaries",
+			text: "→0010000000000xY←",
+			want: []extracted{{3, "0010000000000xY", "0010000000000xYAAQ"}},
+		},
+		{
+			name: "post Summer '23 identifier",
+			text: "see https://example.my.salesforce.com/0011Ab000000062",
+			want: []extracted{{38, "0011Ab000000062", "0011Ab000000062QAA"}},
+		},
+		{name: "all digits", text: "call 555123456789012 or 001000000000000"},
+		{name: "no digits", text: "internationally"},
+		{name: "reserved byte is not zero", text: "commit 3f9a2c1b7d4e8a6"},
+		{name: "lower case hexadecimal", text: "sha 9f8e7d60a1b2c3d"},
+		{name: "check suffix does not match casing", text: "0a3D0000001aH2AAAA"},
+		{name: "invalid check suffix", text: "00d000000000062e9a"},
+		{name: "too long", text: "001000000000000AAAB 0010000000000000"},
+		{name: "too short", text: "00100000000000"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []extracted
+			for _, m := range salesforceid.Extract(tc.text) {
+				got = append(got, extracted{m.Offset, m.Text, m.ID.String()})
+				if tc.text[m.Offset:m.Offset+int64(len(m.Text))] != m.Text {
+					t.Errorf("Offset %d does not point at %s", m.Offset, m.Text)
+				}
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("Extract(%q) = %v, want %v, diff = %s", tc.text, got, tc.want, diff)
+			}
+		})
+	}
+}
+
+func TestExtractReader(t *testing.T) {
+	text := strings.Repeat("lorem ipsum 0010000000000xY ", 1000)
+	count := 0
+	for m, err := range salesforceid.ExtractReader(iotest.OneByteReader(strings.NewReader(text))) {
+		if err != nil {
+			t.Fatalf("didn't expect an error but got %q", err)
+		}
+		if want := int64(count*28 + 12); m.Offset != want {
+			t.Fatalf("match %d Offset = %d, want %d", count, m.Offset, want)
+		}
+		count++
+	}
+	if count != 1000 {
+		t.Errorf("wanted 1000 matches, got %d", count)
+	}
+}
+
+func TestExtractReader_error(t *testing.T) {
+	r := io.MultiReader(strings.NewReader("0010000000000xY "), iotest.ErrReader(io.ErrUnexpectedEOF))
+	var gotErr error
+	count := 0
+	for _, err := range salesforceid.ExtractReader(r) {
+		if err != nil {
+			gotErr = err
+			continue
+		}
+		count++
+	}
+	if count != 1 || !errors.Is(gotErr, io.ErrUnexpectedEOF) {
+		t.Errorf("wanted 1 match and %q, got %d matches and %v", io.ErrUnexpectedEOF, count, gotErr)
+	}
+}
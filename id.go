@@ -0,0 +1,133 @@
+package salesforceid
+
+import "fmt"
+
+// ID is a fixed size representation of an identifier for high throughput
+// use. Unlike SalesforceID, parsing an ID with [ParseBytes] does not allocate.
+// ID is a small value type so its methods have value receivers and the
+// accessors return slices of the copy they were called on.
+//
+// ID is comparable so it can be compared with == and used as a map key. Two
+// IDs are equal if they are the same identifier, regardless of the format,
+// casing, or edition they were parsed with. An ID only holds the identifier
+// so its Edition is derived from it, see [ID.Edition]. Use
+// [SalesforceID.ID] and [ID.SalesforceID] to convert between the two types.
+type ID struct {
+	id [18]byte
+}
+
+// ParseBytes generates an ID without allocating. It accepts the same
+// identifiers and editions as [Parse], including [AutoIdentifierEdition],
+// but edition is only used to validate id: the ID does not keep it.
+func ParseBytes(id []byte, edition IdentifierEdition) (ID, error) {
+	var s ID
+	if err := prepareInto(&s.id, id); err != nil {
+		return ID{}, err
+	}
+	if edition == AutoIdentifierEdition {
+		edition = canonicalEdition(s.id[:])
+	}
+	if edition != PreSummer23IdentifierEdition && edition != PostSummer23IdentifierEdition {
+		return ID{}, fmt.Errorf("%w: %d", ErrInvalidEdition, edition)
+	}
+	if err := checkFields(s.id[:], edition); err != nil {
+		err.Input = string(id)
+		return ID{}, err
+	}
+	return s, nil
+}
+
+// ID converts s to an ID. The zero value converts to the zero ID. The ID may
+// have a different Edition than s, see [ID.Edition].
+func (s *SalesforceID) ID() ID {
+	var id ID
+	if s.fifteen() == nil {
+		return id
+	}
+	copy(id.id[:], s.id)
+	return id
+}
+
+// SalesforceID converts s to a SalesforceID with the Edition of s. The zero ID
+// converts to nil.
+func (s ID) SalesforceID() *SalesforceID {
+	if s.IsZero() {
+		return nil
+	}
+	idBytes := make([]byte, 18)
+	copy(idBytes, s.id[:])
+	sfid, err := fromBytes(idBytes, s.Edition())
+	if err != nil {
+		return nil
+	}
+	return sfid
+}
+
+// IsZero reports whether s is the zero value.
+func (s ID) IsZero() bool {
+	return s.id == [18]byte{}
+}
+
+// Edition returns the edition used to split the PodIdentifier from the
+// Reserved bytes. It is [PostSummer23IdentifierEdition] if the sixth byte is
+// not `0` and [PreSummer23IdentifierEdition] otherwise, regardless of the
+// edition the identifier was parsed with.
+func (s ID) Edition() IdentifierEdition {
+	if s.IsZero() {
+		return 0
+	}
+	return canonicalEdition(s.id[:])
+}
+
+// KeyPrefix returns the first 3 bytes of the identifier.
+func (s ID) KeyPrefix() []byte {
+	return s.id[0:3]
+}
+
+// PodIdentifier returns the 2 or 3 bytes identifying the pod depending on
+// the Edition.
+func (s ID) PodIdentifier() []byte {
+	if s.Edition() == PostSummer23IdentifierEdition {
+		return s.id[3:6]
+	}
+	return s.id[3:5]
+}
+
+// Reserved returns the 2 or 1 reserved bytes depending on the Edition.
+func (s ID) Reserved() []byte {
+	if s.Edition() == PostSummer23IdentifierEdition {
+		return s.id[6:7]
+	}
+	return s.id[5:7]
+}
+
+// NumericIdentifier returns the 8 byte Base62 encoded number of the
+// identifier. It can be decoded with [Decode].
+func (s ID) NumericIdentifier() []byte {
+	return s.id[7:15]
+}
+
+// Suffix returns the 3 check bytes of the 18 character identifier.
+func (s ID) Suffix() []byte {
+	return s.id[15:18]
+}
+
+// AppendFormat appends the identifier in the given format to dst.
+func (s ID) AppendFormat(dst []byte, f Format) []byte {
+	if f == FifteenCharacterFormat {
+		return append(dst, s.id[:15]...)
+	}
+	return append(dst, s.id[:]...)
+}
+
+// Format returns the identifier in the given format.
+func (s ID) Format(f Format) string {
+	if f == FifteenCharacterFormat {
+		return string(s.id[:15])
+	}
+	return string(s.id[:])
+}
+
+func (s ID) String() string {
+	return s.Format(EighteenCharacterFormat)
+}
@@ -0,0 +1,253 @@
+package salesforceid_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sigmavirus24/salesforceid"
+)
+
+func TestParseBytes(t *testing.T) {
+	testCases := []struct {
+		sfid    string
+		edition salesforceid.IdentifierEdition
+		want    *salesforceid.SalesforceID
+		wantErr error
+	}{
+		{
+			sfid:    "00d000000000062eaa",
+			edition: salesforceid.PreSummer23IdentifierEdition,
+			want: &salesforceid.SalesforceID{
+				KeyPrefix:         []byte("00D"),
+				PodIdentifier:     []byte("00"),
+				Reserved:          []byte("00"),
+				NumericIdentifier: []byte("00000062"),
+				Suffix:            []byte("EAA"),
+				Edition:           salesforceid.PreSummer23IdentifierEdition,
+			},
+		},
+		{
+			sfid:    "0011Ab000000062",
+			edition: salesforceid.PostSummer23IdentifierEdition,
+			want: &salesforceid.SalesforceID{
+				KeyPrefix:         []byte("001"),
+				PodIdentifier:     []byte("1Ab"),
+				Reserved:          []byte("0"),
+				NumericIdentifier: []byte("00000062"),
+				Suffix:            []byte("QAA"),
+				Edition:           salesforceid.PostSummer23IdentifierEdition,
+			},
+		},
+		{
+			sfid:    "0011Ab000000062",
+			edition: salesforceid.AutoIdentifierEdition,
+			want: &salesforceid.SalesforceID{
+				KeyPrefix:         []byte("001"),
+				PodIdentifier:     []byte("1Ab"),
+				Reserved:          []byte("0"),
+				NumericIdentifier: []byte("00000062"),
+				Suffix:            []byte("QAA"),
+				Edition:           salesforceid.PostSummer23IdentifierEdition,
+			},
+		},
+		{
+			// The edition is only used for validation and an identifier
+			// whose sixth byte is `0` is split as the pre Summer '23 edition.
+			sfid:    "001300000000062",
+			edition: salesforceid.PostSummer23IdentifierEdition,
+			want: &salesforceid.SalesforceID{
+				KeyPrefix:         []byte("001"),
+				PodIdentifier:     []byte("30"),
+				Reserved:          []byte("00"),
+				NumericIdentifier: []byte("00000062"),
+				Suffix:            []byte("AAA"),
+				Edition:           salesforceid.PreSummer23IdentifierEdition,
+			},
+		},
+		{sfid: "00D00000000006", edition: salesforceid.PreSummer23IdentifierEdition, wantErr: salesforceid.ErrInvalidLengthSFID},
+		{sfid: "001000000000062EAA", edition: salesforceid.PreSummer23IdentifierEdition, wantErr: salesforceid.ErrInvalidSFID},
+		{sfid: "00D 00000000062", edition: salesforceid.PreSummer23IdentifierEdition, wantErr: salesforceid.ErrInvalidCharacter},
+		{sfid: "0011Ab000000062", edition: salesforceid.PreSummer23IdentifierEdition, wantErr: salesforceid.ErrInvalidReserved},
+		{sfid: "0011Ab100000062", edition: salesforceid.PostSummer23IdentifierEdition, wantErr: salesforceid.ErrInvalidReserved},
+		{sfid: "00D000000000062", edition: 0, wantErr: salesforceid.ErrInvalidEdition},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.sfid, func(t *testing.T) {
+			got, err := salesforceid.ParseBytes([]byte(tc.sfid), tc.edition)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Errorf("expected err %q but got %q", tc.wantErr, err)
+				}
+				if !got.IsZero() {
+					t.Errorf("expected the zero value but got %s", got.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("didn't expect an error but got %q", err)
+			}
+			fields := &salesforceid.SalesforceID{
+				KeyPrefix:         got.KeyPrefix(),
+				PodIdentifier:     got.PodIdentifier(),
+				Reserved:          got.Reserved(),
+				NumericIdentifier: got.NumericIdentifier(),
+				Suffix:            got.Suffix(),
+				Edition:           got.Edition(),
+			}
+			if diff := cmp.Diff(fields, tc.want, compareFields); diff != "" {
+				t.Errorf("ParseBytes() = %+v, want %+v, diff = %s", fields, tc.want, diff)
+			}
+			parsed, _ := salesforceid.Parse(tc.sfid, tc.edition)
+			if got.String() != parsed.String() {
+				t.Errorf("ParseBytes().String() = %s, want %s", got.String(), parsed.String())
+			}
+			if f := got.Format(salesforceid.FifteenCharacterFormat); f != parsed.Format(salesforceid.FifteenCharacterFormat) {
+				t.Errorf("ParseBytes().Format(15) = %s, want %s", f, parsed.Format(salesforceid.FifteenCharacterFormat))
+			}
+		})
+	}
+}
+
+func TestParseBytes_allocations(t *testing.T) {
+	for _, sfid := range []string{"0a3d0000001ah2a", "0A3D0000001aH2AKAU", "zzzzz00zzzzzzzz525"} {
+		b := []byte(sfid)
+		var buf [18]byte
+		allocs := testing.AllocsPerRun(100, func() {
+			id, _ := salesforceid.ParseBytes(b, salesforceid.AutoIdentifierEdition)
+			_, _ = salesforceid.Decode(id.NumericIdentifier())
+			_ = id.AppendFormat(buf[:0], salesforceid.EighteenCharacterFormat)
+		})
+		if allocs != 0 {
+			t.Errorf("ParseBytes(%q) allocated %v times, want 0", sfid, allocs)
+		}
+	}
+}
+
+func BenchmarkParseBytes(b *testing.B) {
+	b.Run("15 char sfid", func(b *testing.B) {
+		b.ReportAllocs()
+		id := []byte("0a3d0000001ah2a")
+		for i := 0; i < b.N; i++ {
+			_, _ = salesforceid.ParseBytes(id, salesforceid.PreSummer23IdentifierEdition)
+		}
+	})
+	b.Run("18 char sfid", func(b *testing.B) {
+		b.Run("no changes necessary", func(b *testing.B) {
+			b.ReportAllocs()
+			id := []byte("0A3D0000001aH2AKAU")
+			for i := 0; i < b.N; i++ {
+				_, _ = salesforceid.ParseBytes(id, salesforceid.PreSummer23IdentifierEdition)
+			}
+		})
+		b.Run("everything needs correction", func(b *testing.B) {
+			b.ReportAllocs()
+			id := []byte("zzzzz00zzzzzzzz525")
+			for i := 0; i < b.N; i++ {
+				_, _ = salesforceid.ParseBytes(id, salesforceid.PreSummer23IdentifierEdition)
+			}
+		})
+	})
+}
+
+func TestID_mapKey(t *testing.T) {
+	seen := map[salesforceid.ID]int{}
+	for _, sfid := range []string{"00D000000000062", "00d000000000062eaa", "00D000000000062EAA", "001000000000000", "001000000000000AAA"} {
+		id, err := salesforceid.ParseBytes([]byte(sfid), salesforceid.PreSummer23IdentifierEdition)
+		if err != nil {
+			t.Fatalf("ParseBytes(%q) error = %v", sfid, err)
+		}
+		seen[id]++
+	}
+	if len(seen) != 2 {
+		t.Errorf("wanted 2 distinct identifiers, got %d", len(seen))
+	}
+	org, _ := salesforceid.New("00D000000000062")
+	if seen[org.ID()] != 3 {
+		t.Errorf("wanted 00D000000000062EAA to be seen 3 times, got %d", seen[org.ID()])
+	}
+}
+
+func TestID_equalAcrossEditions(t *testing.T) {
+	for _, sfid := range []string{"001300000000062", "0011Ab000000062QAA"} {
+		t.Run(sfid, func(t *testing.T) {
+			auto, err := salesforceid.ParseBytes([]byte(sfid), salesforceid.AutoIdentifierEdition)
+			if err != nil {
+				t.Fatalf("ParseBytes(%q) error = %v", sfid, err)
+			}
+			post, err := salesforceid.ParseBytes([]byte(sfid), salesforceid.PostSummer23IdentifierEdition)
+			if err != nil {
+				t.Fatalf("ParseBytes(%q) error = %v", sfid, err)
+			}
+			parsed, err := salesforceid.New(sfid)
+			if err != nil {
+				t.Fatalf("New(%q) error = %v", sfid, err)
+			}
+			parsedPost, err := salesforceid.Parse(sfid, salesforceid.PostSummer23IdentifierEdition)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", sfid, err)
+			}
+			if auto != post || parsed.ID() != post || parsedPost.ID() != post {
+				t.Errorf("wanted equal IDs, got %v (auto), %v (post), %v (New), and %v (Parse)", auto, post, parsed.ID(), parsedPost.ID())
+			}
+		})
+	}
+}
+
+func TestID_String(t *testing.T) {
+	id, err := salesforceid.ParseBytes([]byte("00d000000000062eaa"), salesforceid.PreSummer23IdentifierEdition)
+	if err != nil {
+		t.Fatalf("didn't expect an error but got %q", err)
+	}
+	if got := fmt.Sprint(id); got != "00D000000000062EAA" {
+		t.Errorf("fmt.Sprint(id) = %s, want 00D000000000062EAA", got)
+	}
+	names := map[salesforceid.ID]string{id: "Acme"}
+	for id := range names {
+		if got := fmt.Sprintf("%s %s", id, id.KeyPrefix()); got != "00D000000000062EAA 00D" {
+			t.Errorf("fmt.Sprintf() = %s, want 00D000000000062EAA 00D", got)
+		}
+	}
+}
+
+func TestID_SalesforceID(t *testing.T) {
+	testCases := []struct {
+		sfid    string
+		edition salesforceid.IdentifierEdition
+	}{
+		{"00d000000000062eaa", salesforceid.PreSummer23IdentifierEdition},
+		{"0011Ab000000062", salesforceid.PostSummer23IdentifierEdition},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.sfid, func(t *testing.T) {
+			want, err := salesforceid.Parse(tc.sfid, tc.edition)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tc.sfid, err)
+			}
+			id := want.ID()
+			parsed, _ := salesforceid.ParseBytes([]byte(tc.sfid), tc.edition)
+			if id != parsed {
+				t.Errorf("SalesforceID.ID() = %s, want %s", id.String(), parsed.String())
+			}
+			got := id.SalesforceID()
+			if diff := cmp.Diff(got, want, compareFields); diff != "" {
+				t.Errorf("ID.SalesforceID() = %+v, want %+v, diff = %s", got, want, diff)
+			}
+		})
+	}
+}
+
+func TestID_zero(t *testing.T) {
+	var sfid salesforceid.SalesforceID
+	id := sfid.ID()
+	if !id.IsZero() {
+		t.Errorf("wanted the zero ID, got %s", id.String())
+	}
+	if got := id.SalesforceID(); got != nil {
+		t.Errorf("wanted nil, got %s", got)
+	}
+}
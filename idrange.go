@@ -0,0 +1,234 @@
+package salesforceid
+
+import (
+	"bytes"
+	"slices"
+)
+
+// IDRange is an inclusive range of identifiers, i.e., `Id >= Start AND
+// Id <= End`. Both identifiers share the same KeyPrefix, PodIdentifier, and
+// Reserved bytes. Ranges returned by the methods of IDRange have the Edition
+// of the receiver's Start.
+type IDRange struct {
+	Start *SalesforceID
+	End   *SalesforceID
+}
+
+// NewIDRange creates the range from start to end. This returns
+// [ErrMismatchedRange] if start and end differ before the NumericIdentifier
+// and [ErrInvalidRange] if start is after end.
+func NewIDRange(start, end *SalesforceID) (IDRange, error) {
+	if _, _, err := rangeBounds(start, end); err != nil {
+		return IDRange{}, err
+	}
+	return IDRange{Start: start, End: end}, nil
+}
+
+// Len returns the number of identifiers in r. An invalid range has no
+// identifiers.
+func (r IDRange) Len() uint64 {
+	lo, hi, err := rangeBounds(r.Start, r.End)
+	if err != nil {
+		return 0
+	}
+	return hi - lo + 1
+}
+
+// Contains reports whether id is in r.
+func (r IDRange) Contains(id *SalesforceID) bool {
+	lo, hi, err := rangeBounds(r.Start, r.End)
+	if err != nil || id.fifteen() == nil || !r.sameHead(id) {
+		return false
+	}
+	n, err := Decode(id.NumericIdentifier)
+	return err == nil && lo <= n && n <= hi
+}
+
+// Intersect returns the identifiers in both r and o. It reports false if the
+// ranges do not overlap, including when they do not share a KeyPrefix,
+// PodIdentifier, and Reserved bytes.
+func (r IDRange) Intersect(o IDRange) (IDRange, bool) {
+	lo, hi, ok := r.overlap(o)
+	if !ok || lo > hi {
+		return IDRange{}, false
+	}
+	i, err := r.Start.rangeOf(lo, hi)
+	return i, err == nil
+}
+
+// Union returns the identifiers in r or o as one range if they overlap or
+// are adjacent, otherwise as both ranges in order. This returns
+// [ErrMismatchedRange] if the ranges do not share a KeyPrefix, PodIdentifier,
+// and Reserved bytes.
+func (r IDRange) Union(o IDRange) ([]IDRange, error) {
+	return Coalesce(r, o)
+}
+
+// Subtract returns the identifiers in r that are not in o as zero, one, or
+// two ranges in order.
+func (r IDRange) Subtract(o IDRange) []IDRange {
+	lo, hi, err := rangeBounds(r.Start, r.End)
+	if err != nil {
+		return nil
+	}
+	olo, ohi, ok := r.overlap(o)
+	if !ok || olo > ohi {
+		return []IDRange{r}
+	}
+	var ranges []IDRange
+	if lo < olo {
+		if before, err := r.Start.rangeOf(lo, olo-1); err == nil {
+			ranges = append(ranges, before)
+		}
+	}
+	if ohi < hi {
+		if after, err := r.Start.rangeOf(ohi+1, hi); err == nil {
+			ranges = append(ranges, after)
+		}
+	}
+	return ranges
+}
+
+// Split divides r into n consecutive ranges whose lengths differ by at most
+// one. This returns [ErrInvalidChunkSize] if n is 0 or larger than the number
+// of identifiers in r.
+func (r IDRange) Split(n uint64) ([]IDRange, error) {
+	lo, hi, err := rangeBounds(r.Start, r.End)
+	if err != nil {
+		return nil, err
+	}
+	total := hi - lo + 1
+	if n == 0 || n > total {
+		return nil, ErrInvalidChunkSize
+	}
+	size, remainder := total/n, total%n
+	ranges := make([]IDRange, 0, n)
+	for i := uint64(0); i < n; i++ {
+		end := lo + size - 1
+		if i < remainder {
+			end++
+		}
+		part, err := r.Start.rangeOf(lo, end)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, part)
+		lo = end + 1
+	}
+	return ranges, nil
+}
+
+// Coalesce sorts ranges and merges the ones that overlap or are adjacent,
+// which is useful for finding which identifiers were covered by a set of
+// ranges. This returns [ErrMismatchedRange] if the ranges do not all share a
+// KeyPrefix, PodIdentifier, and Reserved bytes.
+func Coalesce(ranges ...IDRange) ([]IDRange, error) {
+	type bounds struct{ lo, hi uint64 }
+	all := make([]bounds, 0, len(ranges))
+	for _, r := range ranges {
+		lo, hi, err := rangeBounds(r.Start, r.End)
+		if err != nil {
+			return nil, err
+		}
+		if !ranges[0].sameHead(r.Start) {
+			return nil, ErrMismatchedRange
+		}
+		all = append(all, bounds{lo, hi})
+	}
+	slices.SortFunc(all, func(a, b bounds) int {
+		switch {
+		case a.lo < b.lo:
+			return -1
+		case a.lo > b.lo:
+			return 1
+		}
+		return 0
+	})
+
+	var merged []IDRange
+	for i := 0; i < len(all); {
+		current := all[i]
+		for i++; i < len(all) && all[i].lo <= current.hi+1; i++ {
+			current.hi = max(current.hi, all[i].hi)
+		}
+		r, err := ranges[0].Start.rangeOf(current.lo, current.hi)
+		if err != nil {
+			return nil, err
+		}
+		merged = append(merged, r)
+	}
+	return merged, nil
+}
+
+// overlap returns the bounds of the overlap of r and o which is empty if lo
+// is greater than hi. It reports false if either range is invalid or they do
+// not share a KeyPrefix, PodIdentifier, and Reserved bytes.
+func (r IDRange) overlap(o IDRange) (uint64, uint64, bool) {
+	lo, hi, err := rangeBounds(r.Start, r.End)
+	if err != nil {
+		return 0, 0, false
+	}
+	olo, ohi, err := rangeBounds(o.Start, o.End)
+	if err != nil || !r.sameHead(o.Start) {
+		return 0, 0, false
+	}
+	return max(lo, olo), min(hi, ohi), true
+}
+
+// sameHead reports whether id shares the KeyPrefix, PodIdentifier, and
+// Reserved bytes of r.
+func (r IDRange) sameHead(id *SalesforceID) bool {
+	return bytes.Equal(r.Start.id[:7], id.id[:7])
+}
+
+// rangeBounds validates the identifiers of a range and returns their decoded
+// NumericIdentifiers.
+func rangeBounds(start, end *SalesforceID) (uint64, uint64, error) {
+	if start.fifteen() == nil || end.fifteen() == nil {
+		return 0, 0, ErrInvalidRange
+	}
+	if !bytes.Equal(start.id[:7], end.id[:7]) {
+		return 0, 0, ErrMismatchedRange
+	}
+	lo, err := Decode(start.NumericIdentifier)
+	if err != nil {
+		return 0, 0, err
+	}
+	hi, err := Decode(end.NumericIdentifier)
+	if err != nil {
+		return 0, 0, err
+	}
+	if lo > hi {
+		return 0, 0, ErrInvalidRange
+	}
+	return lo, hi, nil
+}
+
+// rangeOf returns the range from lo to hi sharing the KeyPrefix,
+// PodIdentifier, Reserved bytes, and Edition of s.
+func (s *SalesforceID) rangeOf(lo, hi uint64) (IDRange, error) {
+	start, err := s.withNumeric(lo)
+	if err != nil {
+		return IDRange{}, err
+	}
+	end, err := s.withNumeric(hi)
+	if err != nil {
+		return IDRange{}, err
+	}
+	return IDRange{Start: start, End: end}, nil
+}
+
+// withNumeric returns a copy of s with its NumericIdentifier set to n.
+func (s *SalesforceID) withNumeric(n uint64) (*SalesforceID, error) {
+	if n >= MaxIdentifierValue {
+		return nil, ErrValueTooLarge
+	}
+	encoded, err := Encode(n)
+	if err != nil {
+		return nil, err
+	}
+	newID := make([]byte, 15)
+	copy(newID, s.id[:7])
+	copy(newID[7:15], encoded)
+	return Parse(string(newID), s.Edition)
+}
@@ -0,0 +1,337 @@
+package salesforceid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sigmavirus24/salesforceid"
+)
+
+func mustRange(t *testing.T, start, end string) salesforceid.IDRange {
+	t.Helper()
+	r, err := salesforceid.NewIDRange(mustNew(t, start), mustNew(t, end))
+	if err != nil {
+		t.Fatalf("NewIDRange(%q, %q) error = %v", start, end, err)
+	}
+	return r
+}
+
+func rangeStrings(ranges []salesforceid.IDRange) [][2]string {
+	var got [][2]string
+	for _, r := range ranges {
+		got = append(got, [2]string{
+			r.Start.Format(salesforceid.FifteenCharacterFormat),
+			r.End.Format(salesforceid.FifteenCharacterFormat),
+		})
+	}
+	return got
+}
+
+func TestNewIDRange(t *testing.T) {
+	testCases := []struct {
+		name    string
+		start   string
+		end     string
+		wantErr error
+	}{
+		{"valid", "001000000000000", "00100000000000z", nil},
+		{"single identifier", "001000000000062", "001000000000062", nil},
+		{"reversed", "00100000000000z", "001000000000000", salesforceid.ErrInvalidRange},
+		{"different key prefix", "001000000000000", "003000000000000", salesforceid.ErrMismatchedRange},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := salesforceid.NewIDRange(mustNew(t, tc.start), mustNew(t, tc.end))
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("expected err %q but got %q", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestIDRange_Len(t *testing.T) {
+	testCases := []struct {
+		start string
+		end   string
+		want  uint64
+	}{
+		{"001000000000000", "001000000000000", 1},
+		{"001000000000000", "00100000000000z", 62},
+		{"001000000000000", "001000000000100", 3845},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.start+"-"+tc.end, func(t *testing.T) {
+			if got := mustRange(t, tc.start, tc.end).Len(); got != tc.want {
+				t.Errorf("Len() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+
+	if got := (salesforceid.IDRange{}).Len(); got != 0 {
+		t.Errorf("Len() of zero IDRange = %d, want 0", got)
+	}
+}
+
+func TestIDRange_Contains(t *testing.T) {
+	r := mustRange(t, "001000000000010", "001000000000020")
+	testCases := []struct {
+		id   string
+		want bool
+	}{
+		{"001000000000010", true},
+		{"001000000000015", true},
+		{"001000000000020", true},
+		{"00100000000000z", false},
+		{"001000000000021", false},
+		{"003000000000015", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.id, func(t *testing.T) {
+			if got := r.Contains(mustNew(t, tc.id)); got != tc.want {
+				t.Errorf("Contains(%q) = %t, want %t", tc.id, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIDRange_Intersect(t *testing.T) {
+	testCases := []struct {
+		name   string
+		a      [2]string
+		b      [2]string
+		want   [2]string
+		wantOK bool
+	}{
+		{
+			name:   "overlapping",
+			a:      [2]string{"001000000000010", "001000000000020"},
+			b:      [2]string{"001000000000015", "001000000000030"},
+			want:   [2]string{"001000000000015", "001000000000020"},
+			wantOK: true,
+		},
+		{
+			name:   "contained",
+			a:      [2]string{"001000000000010", "001000000000020"},
+			b:      [2]string{"001000000000012", "001000000000013"},
+			want:   [2]string{"001000000000012", "001000000000013"},
+			wantOK: true,
+		},
+		{
+			name: "disjoint",
+			a:    [2]string{"001000000000010", "001000000000020"},
+			b:    [2]string{"001000000000021", "001000000000030"},
+		},
+		{
+			name: "different key prefix",
+			a:    [2]string{"001000000000010", "001000000000020"},
+			b:    [2]string{"003000000000010", "003000000000020"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := mustRange(t, tc.a[0], tc.a[1])
+			b := mustRange(t, tc.b[0], tc.b[1])
+			got, ok := a.Intersect(b)
+			if ok != tc.wantOK {
+				t.Fatalf("Intersect() ok = %t, want %t", ok, tc.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if diff := cmp.Diff([][2]string{tc.want}, rangeStrings([]salesforceid.IDRange{got})); diff != "" {
+				t.Errorf("Intersect() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIDRange_Union(t *testing.T) {
+	testCases := []struct {
+		name    string
+		a       [2]string
+		b       [2]string
+		want    [][2]string
+		wantErr error
+	}{
+		{
+			name: "overlapping",
+			a:    [2]string{"001000000000010", "001000000000020"},
+			b:    [2]string{"001000000000015", "001000000000030"},
+			want: [][2]string{{"001000000000010", "001000000000030"}},
+		},
+		{
+			name: "adjacent",
+			a:    [2]string{"001000000000021", "001000000000030"},
+			b:    [2]string{"001000000000010", "001000000000020"},
+			want: [][2]string{{"001000000000010", "001000000000030"}},
+		},
+		{
+			name: "disjoint",
+			a:    [2]string{"001000000000025", "001000000000030"},
+			b:    [2]string{"001000000000010", "001000000000020"},
+			want: [][2]string{
+				{"001000000000010", "001000000000020"},
+				{"001000000000025", "001000000000030"},
+			},
+		},
+		{
+			name:    "different key prefix",
+			a:       [2]string{"001000000000010", "001000000000020"},
+			b:       [2]string{"003000000000010", "003000000000020"},
+			wantErr: salesforceid.ErrMismatchedRange,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := mustRange(t, tc.a[0], tc.a[1])
+			b := mustRange(t, tc.b[0], tc.b[1])
+			got, err := a.Union(b)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected err %q but got %q", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.want, rangeStrings(got)); diff != "" {
+				t.Errorf("Union() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIDRange_Subtract(t *testing.T) {
+	testCases := []struct {
+		name string
+		a    [2]string
+		b    [2]string
+		want [][2]string
+	}{
+		{
+			name: "middle",
+			a:    [2]string{"001000000000010", "001000000000020"},
+			b:    [2]string{"001000000000012", "001000000000018"},
+			want: [][2]string{
+				{"001000000000010", "001000000000011"},
+				{"001000000000019", "001000000000020"},
+			},
+		},
+		{
+			name: "start",
+			a:    [2]string{"001000000000010", "001000000000020"},
+			b:    [2]string{"001000000000000", "001000000000015"},
+			want: [][2]string{{"001000000000016", "001000000000020"}},
+		},
+		{
+			name: "everything",
+			a:    [2]string{"001000000000010", "001000000000020"},
+			b:    [2]string{"001000000000010", "001000000000020"},
+		},
+		{
+			name: "disjoint",
+			a:    [2]string{"001000000000010", "001000000000020"},
+			b:    [2]string{"001000000000021", "001000000000030"},
+			want: [][2]string{{"001000000000010", "001000000000020"}},
+		},
+		{
+			name: "different key prefix",
+			a:    [2]string{"001000000000010", "001000000000020"},
+			b:    [2]string{"003000000000010", "003000000000020"},
+			want: [][2]string{{"001000000000010", "001000000000020"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := mustRange(t, tc.a[0], tc.a[1])
+			b := mustRange(t, tc.b[0], tc.b[1])
+			if diff := cmp.Diff(tc.want, rangeStrings(a.Subtract(b))); diff != "" {
+				t.Errorf("Subtract() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIDRange_Split(t *testing.T) {
+	testCases := []struct {
+		name    string
+		r       [2]string
+		n       uint64
+		want    [][2]string
+		wantErr error
+	}{
+		{
+			name: "even",
+			r:    [2]string{"001000000000000", "001000000000003"},
+			n:    2,
+			want: [][2]string{
+				{"001000000000000", "001000000000001"},
+				{"001000000000002", "001000000000003"},
+			},
+		},
+		{
+			name: "remainder goes to the first parts",
+			r:    [2]string{"001000000000000", "001000000000004"},
+			n:    3,
+			want: [][2]string{
+				{"001000000000000", "001000000000001"},
+				{"001000000000002", "001000000000003"},
+				{"001000000000004", "001000000000004"},
+			},
+		},
+		{
+			name: "one part",
+			r:    [2]string{"001000000000000", "00100000000000z"},
+			n:    1,
+			want: [][2]string{{"001000000000000", "00100000000000z"}},
+		},
+		{
+			name:    "zero parts",
+			r:       [2]string{"001000000000000", "001000000000004"},
+			wantErr: salesforceid.ErrInvalidChunkSize,
+		},
+		{
+			name:    "more parts than identifiers",
+			r:       [2]string{"001000000000000", "001000000000004"},
+			n:       6,
+			wantErr: salesforceid.ErrInvalidChunkSize,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := mustRange(t, tc.r[0], tc.r[1]).Split(tc.n)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected err %q but got %q", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.want, rangeStrings(got)); diff != "" {
+				t.Errorf("Split() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	got, err := salesforceid.Coalesce(
+		mustRange(t, "001000000000030", "001000000000040"),
+		mustRange(t, "001000000000000", "001000000000010"),
+		mustRange(t, "001000000000005", "001000000000020"),
+		mustRange(t, "001000000000021", "001000000000025"),
+	)
+	if err != nil {
+		t.Fatalf("didn't expect an error but got %q", err)
+	}
+	want := [][2]string{
+		{"001000000000000", "001000000000025"},
+		{"001000000000030", "001000000000040"},
+	}
+	if diff := cmp.Diff(want, rangeStrings(got)); diff != "" {
+		t.Errorf("Coalesce() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got, err := salesforceid.Coalesce(); err != nil || got != nil {
+		t.Errorf("Coalesce() = %v, %v, want nil, nil", got, err)
+	}
+}
@@ -0,0 +1,591 @@
+package salesforceid
+
+import (
+	"encoding/binary"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+// arrayMaxSize is the largest number of values a container stores in a
+// sorted array. Beyond this a bitmap is smaller.
+const arrayMaxSize = 4096
+
+// bitmapWords is the number of words needed for a bitmap of every uint16.
+const bitmapWords = 1 << 16 / 64
+
+// idSetVersion is written at the start of the binary encoding of an IDSet.
+const idSetVersion = 1
+
+// IDSet is a compressed set of identifiers. Identifiers are grouped by their
+// first 7 bytes, i.e., their KeyPrefix, PodIdentifier, and Reserved bytes,
+// and the decoded NumericIdentifiers of each group are stored in the same way as a Roaring
+// bitmap: the upper bits select a container and the lower 16 bits are stored
+// in either a sorted array or a bitmap depending on how many there are.
+//
+// The Edition of an identifier does not affect whether it is in the set.
+// Identifiers whose sixth byte is `0` are valid in either edition and are
+// yielded as [PreSummer23IdentifierEdition].
+//
+// The zero value is an empty set ready to use. An IDSet is not safe for
+// concurrent use.
+type IDSet struct {
+	groups map[uint64]*idGroup
+}
+
+// idGroup holds the containers of identifiers sharing a head. The keys are
+// the upper bits of the NumericIdentifier and are kept sorted.
+type idGroup struct {
+	keys       []uint32
+	containers []*container
+}
+
+// container holds the lower 16 bits of the NumericIdentifiers sharing a key
+// in either array or bitmap. n is the number of bits set in bitmap.
+type container struct {
+	array  []uint16
+	bitmap *[bitmapWords]uint64
+	n      int
+}
+
+// Add adds id to the set. This returns an error if id cannot be parsed into
+// its fields.
+func (s *IDSet) Add(id *SalesforceID) error {
+	packed, numeric, err := setKey(id)
+	if err != nil {
+		return err
+	}
+	if s.groups == nil {
+		s.groups = map[uint64]*idGroup{}
+	}
+	g, ok := s.groups[packed]
+	if !ok {
+		g = &idGroup{}
+		s.groups[packed] = g
+	}
+	key := uint32(numeric >> 16)
+	i, ok := slices.BinarySearch(g.keys, key)
+	if !ok {
+		g.keys = slices.Insert(g.keys, i, key)
+		g.containers = slices.Insert(g.containers, i, &container{})
+	}
+	g.containers[i].add(uint16(numeric))
+	return nil
+}
+
+// Contains reports whether id is in the set.
+func (s *IDSet) Contains(id *SalesforceID) bool {
+	packed, numeric, err := setKey(id)
+	if err != nil {
+		return false
+	}
+	g, ok := s.groups[packed]
+	if !ok {
+		return false
+	}
+	i, ok := slices.BinarySearch(g.keys, uint32(numeric>>16))
+	return ok && g.containers[i].contains(uint16(numeric))
+}
+
+// Len returns the number of identifiers in the set.
+func (s *IDSet) Len() int {
+	n := 0
+	for _, g := range s.groups {
+		for _, c := range g.containers {
+			n += c.len()
+		}
+	}
+	return n
+}
+
+// All returns an iterator over the identifiers in the set. Identifiers that
+// share their first 7 bytes are yielded in the same order
+// as [Compare].
+func (s *IDSet) All() iter.Seq[*SalesforceID] {
+	return func(yield func(*SalesforceID) bool) {
+		for _, packed := range s.sortedHeads() {
+			g := s.groups[packed]
+			for i, c := range g.containers {
+				high := uint64(g.keys[i]) << 16
+				for low := range c.values {
+					id, err := unpack(packed, high|uint64(low))
+					if err != nil {
+						continue
+					}
+					if !yield(id) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// Union returns a new set of the identifiers in s or o.
+func (s *IDSet) Union(o *IDSet) *IDSet {
+	u := &IDSet{groups: map[uint64]*idGroup{}}
+	for packed, g := range s.groups {
+		u.groups[packed] = g.clone()
+	}
+	for packed, og := range o.groups {
+		g, ok := u.groups[packed]
+		if !ok {
+			u.groups[packed] = og.clone()
+			continue
+		}
+		u.groups[packed] = g.merge(og, (*container).union, true)
+	}
+	return u
+}
+
+// Intersect returns a new set of the identifiers in both s and o.
+func (s *IDSet) Intersect(o *IDSet) *IDSet {
+	u := &IDSet{groups: map[uint64]*idGroup{}}
+	for packed, g := range s.groups {
+		og, ok := o.groups[packed]
+		if !ok {
+			continue
+		}
+		if merged := g.merge(og, (*container).intersect, false); len(merged.keys) > 0 {
+			u.groups[packed] = merged
+		}
+	}
+	return u
+}
+
+// Difference returns a new set of the identifiers in s that are not in o.
+func (s *IDSet) Difference(o *IDSet) *IDSet {
+	u := &IDSet{groups: map[uint64]*idGroup{}}
+	for packed, g := range s.groups {
+		og, ok := o.groups[packed]
+		if !ok {
+			u.groups[packed] = g.clone()
+			continue
+		}
+		merged := &idGroup{}
+		for i, key := range g.keys {
+			c := g.containers[i]
+			if j, ok := slices.BinarySearch(og.keys, key); ok {
+				c = c.difference(og.containers[j])
+			} else {
+				c = c.clone()
+			}
+			if c != nil {
+				merged.keys = append(merged.keys, key)
+				merged.containers = append(merged.containers, c)
+			}
+		}
+		if len(merged.keys) > 0 {
+			u.groups[packed] = merged
+		}
+	}
+	return u
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler]. The encoding starts
+// with a version byte and the number of groups as a varint. Each group is
+// written in order as its KeyPrefix, PodIdentifier, Reserved bytes, and
+// Edition packed into 6 bytes as in [SalesforceID.AppendBinary] followed by
+// the number of containers as a varint. Each container is written as the
+// difference from the previous container's key and the number of values less
+// one as varints followed by either the big-endian values or, when there are
+// more than 4096, a bitmap of 1024 big-endian words.
+func (s *IDSet) MarshalBinary() ([]byte, error) {
+	heads := s.sortedHeads()
+	b := []byte{idSetVersion}
+	b = binary.AppendUvarint(b, uint64(len(heads)))
+	for _, packed := range heads {
+		g := s.groups[packed]
+		var head [8]byte
+		binary.BigEndian.PutUint64(head[:], packed)
+		b = append(b, head[2:]...)
+		b = binary.AppendUvarint(b, uint64(len(g.keys)))
+		var previous uint32
+		for i, key := range g.keys {
+			c := g.containers[i]
+			b = binary.AppendUvarint(b, uint64(key-previous))
+			b = binary.AppendUvarint(b, uint64(c.len()-1))
+			previous = key
+			if c.bitmap == nil {
+				for _, v := range c.array {
+					b = binary.BigEndian.AppendUint16(b, v)
+				}
+				continue
+			}
+			for _, word := range c.bitmap {
+				b = binary.BigEndian.AppendUint64(b, word)
+			}
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler]. It accepts the
+// encoding produced by [IDSet.MarshalBinary] and replaces the contents of s.
+// This returns [ErrInvalidBinary] if data is malformed.
+func (s *IDSet) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != idSetVersion {
+		return ErrInvalidBinary
+	}
+	r := setReader{data: data[1:]}
+	groups := map[uint64]*idGroup{}
+	count := r.uvarint()
+	for range min(count, uint64(len(data))) {
+		var head [8]byte
+		copy(head[2:], r.next(6))
+		packed := binary.BigEndian.Uint64(head[:])
+		id, err := unpack(packed, 0)
+		if err != nil || id.Edition != canonicalEdition(id.id) {
+			return ErrInvalidBinary
+		}
+		if _, ok := groups[packed]; ok {
+			return ErrInvalidBinary
+		}
+		g := &idGroup{}
+		containers := r.uvarint()
+		var key uint64
+		for i := range min(containers, uint64(len(data))) {
+			delta := r.uvarint()
+			if i > 0 && delta == 0 {
+				return ErrInvalidBinary
+			}
+			if delta > MaxIdentifierValue>>16 {
+				return ErrInvalidBinary
+			}
+			key += delta
+			n := r.uvarint() + 1
+			if n > 1<<16 {
+				return ErrInvalidBinary
+			}
+			c, ok := r.container(int(n))
+			if !ok || key<<16|uint64(c.last()) >= MaxIdentifierValue {
+				return ErrInvalidBinary
+			}
+			g.keys = append(g.keys, uint32(key))
+			g.containers = append(g.containers, c)
+		}
+		if uint64(len(g.keys)) != containers || containers == 0 {
+			return ErrInvalidBinary
+		}
+		groups[packed] = g
+	}
+	if uint64(len(groups)) != count || r.err || len(r.data) != 0 {
+		return ErrInvalidBinary
+	}
+	s.groups = groups
+	return nil
+}
+
+// sortedHeads returns the keys of s.groups in order.
+func (s *IDSet) sortedHeads() []uint64 {
+	heads := make([]uint64, 0, len(s.groups))
+	for packed := range s.groups {
+		heads = append(heads, packed)
+	}
+	slices.Sort(heads)
+	return heads
+}
+
+// setKey returns the packed head and decoded NumericIdentifier of id.
+func setKey(id *SalesforceID) (uint64, uint64, error) {
+	if id.fifteen() == nil {
+		return 0, 0, ErrInvalidLengthSFID
+	}
+	packed, err := packHead(id.id, canonicalEdition(id.id))
+	if err != nil {
+		return 0, 0, err
+	}
+	numeric, err := Decode(id.NumericIdentifier)
+	if err != nil {
+		return 0, 0, err
+	}
+	return packed, numeric, nil
+}
+
+// setReader reads the encoding written by IDSet.MarshalBinary and records
+// whether any read ran past the end of data.
+type setReader struct {
+	data []byte
+	err  bool
+}
+
+func (r *setReader) next(n int) []byte {
+	if n > len(r.data) {
+		r.err = true
+		r.data = nil
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *setReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = true
+		r.data = nil
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+// container reads a container of n values. It reports false if the values
+// are not sorted or the bitmap does not hold n values.
+func (r *setReader) container(n int) (*container, bool) {
+	if n > arrayMaxSize {
+		b := r.next(bitmapWords * 8)
+		if b == nil {
+			return nil, false
+		}
+		c := &container{bitmap: new([bitmapWords]uint64), n: n}
+		count := 0
+		for i := range c.bitmap {
+			c.bitmap[i] = binary.BigEndian.Uint64(b[i*8:])
+			count += bits.OnesCount64(c.bitmap[i])
+		}
+		return c, count == n
+	}
+	b := r.next(n * 2)
+	if b == nil {
+		return nil, false
+	}
+	c := &container{array: make([]uint16, n)}
+	for i := range c.array {
+		c.array[i] = binary.BigEndian.Uint16(b[i*2:])
+		if i > 0 && c.array[i] <= c.array[i-1] {
+			return nil, false
+		}
+	}
+	return c, true
+}
+
+// clone returns a deep copy of g.
+func (g *idGroup) clone() *idGroup {
+	c := &idGroup{
+		keys:       slices.Clone(g.keys),
+		containers: make([]*container, len(g.containers)),
+	}
+	for i, ct := range g.containers {
+		c.containers[i] = ct.clone()
+	}
+	return c
+}
+
+// merge combines the containers of g and o that share a key with op,
+// dropping empty results. If keepAll is true, containers whose key is only
+// in one of the groups are copied into the result.
+func (g *idGroup) merge(o *idGroup, op func(*container, *container) *container, keepAll bool) *idGroup {
+	merged := &idGroup{}
+	add := func(key uint32, c *container) {
+		if c != nil {
+			merged.keys = append(merged.keys, key)
+			merged.containers = append(merged.containers, c)
+		}
+	}
+	i, j := 0, 0
+	for i < len(g.keys) && j < len(o.keys) {
+		switch a, b := g.keys[i], o.keys[j]; {
+		case a < b:
+			if keepAll {
+				add(a, g.containers[i].clone())
+			}
+			i++
+		case a > b:
+			if keepAll {
+				add(b, o.containers[j].clone())
+			}
+			j++
+		default:
+			add(a, op(g.containers[i], o.containers[j]))
+			i++
+			j++
+		}
+	}
+	if keepAll {
+		for ; i < len(g.keys); i++ {
+			add(g.keys[i], g.containers[i].clone())
+		}
+		for ; j < len(o.keys); j++ {
+			add(o.keys[j], o.containers[j].clone())
+		}
+	}
+	return merged
+}
+
+func (c *container) len() int {
+	if c.bitmap != nil {
+		return c.n
+	}
+	return len(c.array)
+}
+
+func (c *container) contains(v uint16) bool {
+	if c.bitmap != nil {
+		return c.bitmap[v>>6]&(1<<(v&63)) != 0
+	}
+	_, ok := slices.BinarySearch(c.array, v)
+	return ok
+}
+
+func (c *container) add(v uint16) {
+	if c.bitmap != nil {
+		if !c.contains(v) {
+			c.bitmap[v>>6] |= 1 << (v & 63)
+			c.n++
+		}
+		return
+	}
+	i, ok := slices.BinarySearch(c.array, v)
+	if ok {
+		return
+	}
+	c.array = slices.Insert(c.array, i, v)
+	if len(c.array) > arrayMaxSize {
+		c.bitmap, c.n, c.array = c.bits(), len(c.array), nil
+	}
+}
+
+// last returns the largest value in c which must not be empty.
+func (c *container) last() uint16 {
+	if c.bitmap == nil {
+		return c.array[len(c.array)-1]
+	}
+	for i := len(c.bitmap) - 1; i > 0; i-- {
+		if c.bitmap[i] != 0 {
+			return uint16(i<<6 | (63 - bits.LeadingZeros64(c.bitmap[i])))
+		}
+	}
+	return uint16(63 - bits.LeadingZeros64(c.bitmap[0]))
+}
+
+// values yields the values of c in order.
+func (c *container) values(yield func(uint16) bool) {
+	if c.bitmap == nil {
+		for _, v := range c.array {
+			if !yield(v) {
+				return
+			}
+		}
+		return
+	}
+	for i, word := range c.bitmap {
+		for word != 0 {
+			if !yield(uint16(i<<6 | bits.TrailingZeros64(word))) {
+				return
+			}
+			word &= word - 1
+		}
+	}
+}
+
+// bits returns c as a bitmap which must not be modified.
+func (c *container) bits() *[bitmapWords]uint64 {
+	if c.bitmap != nil {
+		return c.bitmap
+	}
+	b := new([bitmapWords]uint64)
+	for _, v := range c.array {
+		b[v>>6] |= 1 << (v & 63)
+	}
+	return b
+}
+
+func (c *container) clone() *container {
+	if c.bitmap == nil {
+		return &container{array: slices.Clone(c.array)}
+	}
+	b := *c.bitmap
+	return &container{bitmap: &b, n: c.n}
+}
+
+func (c *container) union(o *container) *container {
+	if c.bitmap != nil || o.bitmap != nil || len(c.array)+len(o.array) > arrayMaxSize {
+		b := *c.bits()
+		for i, word := range o.bits() {
+			b[i] |= word
+		}
+		return fromBitmap(&b)
+	}
+	merged := make([]uint16, 0, len(c.array)+len(o.array))
+	i, j := 0, 0
+	for i < len(c.array) && j < len(o.array) {
+		switch a, b := c.array[i], o.array[j]; {
+		case a < b:
+			merged = append(merged, a)
+			i++
+		case a > b:
+			merged = append(merged, b)
+			j++
+		default:
+			merged = append(merged, a)
+			i++
+			j++
+		}
+	}
+	merged = append(merged, c.array[i:]...)
+	return &container{array: append(merged, o.array[j:]...)}
+}
+
+func (c *container) intersect(o *container) *container {
+	if c.bitmap != nil && o.bitmap != nil {
+		b := *c.bitmap
+		for i, word := range o.bitmap {
+			b[i] &= word
+		}
+		return fromBitmap(&b)
+	}
+	small, large := c, o
+	if small.bitmap != nil {
+		small, large = o, c
+	}
+	return filter(small.array, func(v uint16) bool { return large.contains(v) })
+}
+
+func (c *container) difference(o *container) *container {
+	if c.bitmap == nil {
+		return filter(c.array, func(v uint16) bool { return !o.contains(v) })
+	}
+	b := *c.bitmap
+	for i, word := range o.bits() {
+		b[i] &^= word
+	}
+	return fromBitmap(&b)
+}
+
+// filter returns a container of the values for which keep returns true or
+// nil if there are none.
+func filter(values []uint16, keep func(uint16) bool) *container {
+	var kept []uint16
+	for _, v := range values {
+		if keep(v) {
+			kept = append(kept, v)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return &container{array: kept}
+}
+
+// fromBitmap returns a container of the values in b, using an array if there
+// are few enough values, or nil if there are none.
+func fromBitmap(b *[bitmapWords]uint64) *container {
+	n := 0
+	for _, word := range b {
+		n += bits.OnesCount64(word)
+	}
+	c := &container{bitmap: b, n: n}
+	switch {
+	case n == 0:
+		return nil
+	case n > arrayMaxSize:
+		return c
+	}
+	array := make([]uint16, 0, n)
+	for v := range c.values {
+		array = append(array, v)
+	}
+	return &container{array: array}
+}
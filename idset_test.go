@@ -0,0 +1,249 @@
+package salesforceid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sigmavirus24/salesforceid"
+)
+
+// newSet adds count identifiers starting at start to a new set.
+func newSet(t *testing.T, start string, count uint64) *salesforceid.IDSet {
+	t.Helper()
+	s := &salesforceid.IDSet{}
+	addRange(t, s, start, count)
+	return s
+}
+
+func addRange(t *testing.T, s *salesforceid.IDSet, start string, count uint64) {
+	t.Helper()
+	id := mustNew(t, start)
+	for i := uint64(0); i < count; i++ {
+		next, err := id.Add(i)
+		if err != nil {
+			t.Fatalf("Add(%d) error = %v", i, err)
+		}
+		if err := s.Add(next); err != nil {
+			t.Fatalf("IDSet.Add(%s) error = %v", next, err)
+		}
+	}
+}
+
+func setStrings(s *salesforceid.IDSet) []string {
+	var got []string
+	for id := range s.All() {
+		got = append(got, id.Format(salesforceid.FifteenCharacterFormat))
+	}
+	return got
+}
+
+func TestIDSet_Add(t *testing.T) {
+	var s salesforceid.IDSet
+	for _, id := range []string{"003000000000001", "001000000000002", "001000000000001", "001000000000002"} {
+		if err := s.Add(mustNew(t, id)); err != nil {
+			t.Fatalf("didn't expect an error but got %q", err)
+		}
+	}
+	if got := s.Len(); got != 3 {
+		t.Errorf("Len() = %d, want 3", got)
+	}
+	want := []string{"001000000000001", "001000000000002", "003000000000001"}
+	if diff := cmp.Diff(want, setStrings(&s)); diff != "" {
+		t.Errorf("All() mismatch (-want +got):\n%s", diff)
+	}
+	if err := s.Add(&salesforceid.SalesforceID{}); !errors.Is(err, salesforceid.ErrInvalidLengthSFID) {
+		t.Errorf("expected err %q but got %q", salesforceid.ErrInvalidLengthSFID, err)
+	}
+}
+
+func TestIDSet_Contains(t *testing.T) {
+	s := newSet(t, "001000000000000", 5000)
+	post, err := salesforceid.Parse("00100000000000a", salesforceid.PostSummer23IdentifierEdition)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	testCases := []struct {
+		name string
+		id   *salesforceid.SalesforceID
+		want bool
+	}{
+		{"first", mustNew(t, "001000000000000"), true},
+		{"in bitmap", mustNew(t, "00100000000011A"), true},
+		{"after last", mustNew(t, "001000000001Ie8"), false},
+		{"different key prefix", mustNew(t, "003000000000000"), false},
+		{"different edition", post, true},
+		{"zero value", &salesforceid.SalesforceID{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := s.Contains(tc.id); got != tc.want {
+				t.Errorf("Contains(%s) = %t, want %t", tc.id, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIDSet_ignoresEdition(t *testing.T) {
+	pre := mustNew(t, "001300000000062")
+	post, err := salesforceid.Parse("001300000000062", salesforceid.PostSummer23IdentifierEdition)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	s := &salesforceid.IDSet{}
+	for _, id := range []*salesforceid.SalesforceID{pre, post} {
+		if err := s.Add(id); err != nil {
+			t.Fatalf("didn't expect an error but got %q", err)
+		}
+	}
+	if s.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", s.Len())
+	}
+	if !s.Contains(pre) || !s.Contains(post) {
+		t.Errorf("wanted the set to contain %s in either edition", pre)
+	}
+}
+
+func TestIDSet_All(t *testing.T) {
+	s := newSet(t, "0010000000zzzzy", 4)
+	want := []string{"0010000000zzzzy", "0010000000zzzzz", "001000000100000", "001000000100001"}
+	if diff := cmp.Diff(want, setStrings(s)); diff != "" {
+		t.Errorf("All() mismatch (-want +got):\n%s", diff)
+	}
+
+	count := 0
+	for range s.All() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("expected iteration to stop after 1 identifier but got %d", count)
+	}
+}
+
+func TestIDSet_operations(t *testing.T) {
+	testCases := []struct {
+		name           string
+		aCount         uint64
+		bOffset        uint64
+		bCount         uint64
+		wantUnion      int
+		wantIntersect  int
+		wantDifference int
+	}{
+		{"arrays", 10, 5, 20, 25, 5, 5},
+		{"bitmaps", 6000, 1000, 8000, 9000, 5000, 1000},
+		{"array and bitmap", 100, 0, 8000, 8000, 100, 0},
+		{"bitmap and array", 8000, 0, 100, 8000, 100, 7900},
+		{"across containers", 70_000, 65_000, 10_000, 75_000, 5000, 65_000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newSet(t, "001000000000000", tc.aCount)
+			start, err := mustNew(t, "001000000000000").Add(tc.bOffset)
+			if err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+			b := newSet(t, start.String(), tc.bCount)
+			if err := b.Add(mustNew(t, "003000000000000")); err != nil {
+				t.Fatalf("didn't expect an error but got %q", err)
+			}
+
+			if got := a.Union(b).Len(); got != tc.wantUnion+1 {
+				t.Errorf("Union().Len() = %d, want %d", got, tc.wantUnion+1)
+			}
+			if got := a.Intersect(b).Len(); got != tc.wantIntersect {
+				t.Errorf("Intersect().Len() = %d, want %d", got, tc.wantIntersect)
+			}
+			if got := a.Difference(b).Len(); got != tc.wantDifference {
+				t.Errorf("Difference().Len() = %d, want %d", got, tc.wantDifference)
+			}
+			if got := a.Len(); got != int(tc.aCount) {
+				t.Errorf("expected operations to not modify the set but Len() = %d", got)
+			}
+		})
+	}
+}
+
+func TestIDSet_Union_doesNotShareContainers(t *testing.T) {
+	a := newSet(t, "001000000000000", 10)
+	u := a.Union(&salesforceid.IDSet{})
+	if err := u.Add(mustNew(t, "00100000000000z")); err != nil {
+		t.Fatalf("didn't expect an error but got %q", err)
+	}
+	if a.Contains(mustNew(t, "00100000000000z")) {
+		t.Error("expected adding to the union to not modify the original set")
+	}
+}
+
+func TestIDSet_MarshalBinary(t *testing.T) {
+	s := newSet(t, "001000000000000", 70_000)
+	addRange(t, s, "0030000000zzzzz", 3)
+	post, err := salesforceid.Parse("0011Ab000000001", salesforceid.PostSummer23IdentifierEdition)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := s.Add(post); err != nil {
+		t.Fatalf("didn't expect an error but got %q", err)
+	}
+
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("didn't expect an error but got %q", err)
+	}
+	var got salesforceid.IDSet
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("didn't expect an error but got %q", err)
+	}
+	if diff := cmp.Diff(setStrings(s), setStrings(&got)); diff != "" {
+		t.Errorf("UnmarshalBinary() mismatch (-want +got):\n%s", diff)
+	}
+	if !got.Contains(post) {
+		t.Errorf("expected %s to keep its edition", post)
+	}
+
+	empty, err := (&salesforceid.IDSet{}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("didn't expect an error but got %q", err)
+	}
+	if err := got.UnmarshalBinary(empty); err != nil || got.Len() != 0 {
+		t.Errorf("UnmarshalBinary(empty) = %v with %d identifiers", err, got.Len())
+	}
+}
+
+func TestIDSet_UnmarshalBinary_invalid(t *testing.T) {
+	s := newSet(t, "001000000000000", 3)
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("didn't expect an error but got %q", err)
+	}
+	unsorted := append([]byte(nil), data...)
+	unsorted[len(unsorted)-1] = 0
+	// The identifiers have a `0` sixth byte so are stored as the pre Summer
+	// '23 edition.
+	postEdition := append([]byte(nil), data...)
+	postEdition[7] = postEdition[7]&^0b11 | 2
+
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"unknown version", append([]byte{2}, data[1:]...)},
+		{"truncated", data[:len(data)-1]},
+		{"trailing bytes", append(append([]byte(nil), data...), 0)},
+		{"unsorted values", unsorted},
+		{"non-canonical edition", postEdition},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got salesforceid.IDSet
+			if err := got.UnmarshalBinary(tc.data); !errors.Is(err, salesforceid.ErrInvalidBinary) {
+				t.Errorf("expected err %q but got %q", salesforceid.ErrInvalidBinary, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package salesforceid
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// knownInstances are pod identifiers that have been observed on identifiers
+// created on a given instance. This list is best-effort and incomplete:
+// Salesforce does not publish the mapping and instances are split, migrated,
+// and renamed over time. Use [RegisterInstance] or
+// [InstanceRegistry.LoadInstances] to correct or extend it.
+var knownInstances = map[string]string{
+	"20": "EU0",
+	"30": "NA1",
+	"40": "NA2",
+	"50": "NA3",
+	"60": "NA4",
+	"70": "AP0",
+	"80": "NA6",
+	"90": "NA5",
+	"A0": "NA7",
+	"b0": "EU1",
+	"C0": "NA8",
+	"D0": "EU2",
+	"E0": "NA9",
+	"F0": "NA10",
+	"G0": "NA11",
+	"i0": "NA12",
+	"j0": "EU3",
+	"K0": "NA14",
+	"3m": "NA15",
+	"U0": "NA16",
+	"o0": "AP1",
+	"w0": "EU5",
+}
+
+// InstanceRegistry maps the PodIdentifier of a SalesforceID to the name of
+// the instance (a.k.a., Pod, Server) on which the record was created. Pod
+// identifiers are 2 bytes for [PreSummer23IdentifierEdition] and 3 bytes for
+// [PostSummer23IdentifierEdition]. An InstanceRegistry is safe for concurrent
+// use.
+type InstanceRegistry struct {
+	mu        sync.RWMutex
+	instances map[string]string
+}
+
+// DefaultInstances is the registry used by [SalesforceID.Instance].
+var DefaultInstances = NewKnownInstanceRegistry()
+
+// NewInstanceRegistry creates an empty InstanceRegistry.
+func NewInstanceRegistry() *InstanceRegistry {
+	return &InstanceRegistry{instances: map[string]string{}}
+}
+
+// NewKnownInstanceRegistry creates an InstanceRegistry populated with the
+// pod identifiers this package knows about.
+func NewKnownInstanceRegistry() *InstanceRegistry {
+	r := NewInstanceRegistry()
+	for pod, instance := range knownInstances {
+		r.instances[pod] = instance
+	}
+	return r
+}
+
+// Register associates pod with the instance name. Registering a pod that is
+// already present replaces its name. This returns [ErrInvalidPodIdentifier]
+// if pod is not 2 or 3 bytes long.
+func (r *InstanceRegistry) Register(pod, instance string) error {
+	if len(pod) != 2 && len(pod) != 3 {
+		return ErrInvalidPodIdentifier
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.instances[pod] = instance
+	return nil
+}
+
+// LoadInstances adds the pod identifiers in a JSON object mapping pod
+// identifiers to instance names (e.g., `{"30": "NA1", "a1B": "USA123"}`) to
+// the registry. Nothing is added if the object cannot be decoded or contains
+// an invalid pod identifier.
+func (r *InstanceRegistry) LoadInstances(rd io.Reader) error {
+	var instances map[string]string
+	if err := json.NewDecoder(rd).Decode(&instances); err != nil {
+		return fmt.Errorf("decoding instances: %w", err)
+	}
+	for pod := range instances {
+		if len(pod) != 2 && len(pod) != 3 {
+			return fmt.Errorf("%w: %q", ErrInvalidPodIdentifier, pod)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for pod, instance := range instances {
+		r.instances[pod] = instance
+	}
+	return nil
+}
+
+// Lookup returns the instance name for pod. Instances that existed before
+// Summer '23 keep their 2 byte identifier followed by what used to be a
+// reserved `0`, so a 3 byte pod ending in `0` falls back to its first 2
+// bytes. This returns an [*UnknownPodIdentifierError] if the pod has not been
+// registered.
+func (r *InstanceRegistry) Lookup(pod []byte) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if instance, ok := r.instances[string(pod)]; ok {
+		return instance, nil
+	}
+	if len(pod) == 3 && pod[2] == '0' {
+		if instance, ok := r.instances[string(pod[:2])]; ok {
+			return instance, nil
+		}
+	}
+	return "", &UnknownPodIdentifierError{PodIdentifier: string(pod)}
+}
+
+// has reports whether pod was registered without falling back to a shorter
+// pod identifier.
+func (r *InstanceRegistry) has(pod []byte) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.instances[string(pod)]
+	return ok
+}
+
+// Instance returns the name of the instance on which s was created.
+func (r *InstanceRegistry) Instance(s *SalesforceID) (string, error) {
+	return r.Lookup(s.PodIdentifier)
+}
+
+// RegisterInstance adds a pod identifier to [DefaultInstances].
+func RegisterInstance(pod, instance string) error {
+	return DefaultInstances.Register(pod, instance)
+}
+
+// Instance returns the name of the instance on which the record was created
+// using [DefaultInstances].
+func (s *SalesforceID) Instance() (string, error) {
+	return DefaultInstances.Instance(s)
+}
@@ -0,0 +1,96 @@
+package salesforceid_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sigmavirus24/salesforceid"
+)
+
+func TestSalesforceID_Instance(t *testing.T) {
+	// Register instances on a copy so DefaultInstances, which edition
+	// detection also consults, is left unchanged for other tests.
+	r := salesforceid.NewKnownInstanceRegistry()
+	if err := r.Register("1Ab", "USA42S"); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if got, err := mustNew(t, "001300000000062").Instance(); err != nil || got != "NA1" {
+		t.Errorf("Instance() = %s, %v, want NA1", got, err)
+	}
+	if _, err := mustNew(t, "0011Ab000000062").Instance(); !errors.Is(err, salesforceid.ErrUnknownPodIdentifier) {
+		t.Errorf("expected err to match %q but got %q", salesforceid.ErrUnknownPodIdentifier, err)
+	}
+	testCases := []struct {
+		sfid    string
+		edition salesforceid.IdentifierEdition
+		want    string
+		wantErr bool
+	}{
+		{"001300000000062", salesforceid.PreSummer23IdentifierEdition, "NA1", false},
+		{"001300000000062", salesforceid.PostSummer23IdentifierEdition, "NA1", false},
+		{"001w00000000062", salesforceid.PreSummer23IdentifierEdition, "EU5", false},
+		{"0011Ab000000062", salesforceid.PostSummer23IdentifierEdition, "USA42S", false},
+		{"0011Ab000000062", salesforceid.PreSummer23IdentifierEdition, "", true},
+		{"001zz0000000062", salesforceid.PreSummer23IdentifierEdition, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.sfid, func(t *testing.T) {
+			id, err := salesforceid.Parse(tc.sfid, tc.edition)
+			if err != nil {
+				if tc.wantErr {
+					return
+				}
+				t.Fatalf("Parse(%q) error = %v", tc.sfid, err)
+			}
+			got, err := r.Instance(id)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Instance() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr && !errors.Is(err, salesforceid.ErrUnknownPodIdentifier) {
+				t.Errorf("expected err to match %q but got %q", salesforceid.ErrUnknownPodIdentifier, err)
+			}
+			if got != tc.want {
+				t.Errorf("Instance() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInstanceRegistry_LoadInstances(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		pod     string
+		want    string
+		wantErr error
+	}{
+		{"two byte pod", `{"30": "NA1"}`, "30", "NA1", nil},
+		{"three byte pod", `{"a1B": "USA123"}`, "a1B", "USA123", nil},
+		{"invalid pod", `{"a": "Short"}`, "", "", salesforceid.ErrInvalidPodIdentifier},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := salesforceid.NewInstanceRegistry()
+			err := r.LoadInstances(strings.NewReader(tc.input))
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Errorf("expected err %q but got %q", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadInstances() error = %v", err)
+			}
+			got, err := r.Lookup([]byte(tc.pod))
+			if err != nil {
+				t.Fatalf("Lookup(%q) error = %v", tc.pod, err)
+			}
+			if got != tc.want {
+				t.Errorf("Lookup(%q) = %s, want %s", tc.pod, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package salesforceid
+
+import "sync"
+
+// standardKeyPrefixes are the key prefixes of standard objects which are the
+// same in every org. Custom objects (e.g., `a0X`) have prefixes that differ
+// between orgs and must be registered by the user.
+// See also:
+// * https://www.fishofprey.com/2011/09/obscure-salesforce-object-key-prefixes.html
+var standardKeyPrefixes = map[string]string{
+	"001": "Account",
+	"002": "Note",
+	"003": "Contact",
+	"005": "User",
+	"006": "Opportunity",
+	"007": "Activity",
+	"00B": "ListView",
+	"00D": "Organization",
+	"00E": "UserRole",
+	"00G": "Group",
+	"00I": "Partner",
+	"00N": "CustomFieldDefinition",
+	"00O": "Report",
+	"00P": "Attachment",
+	"00Q": "Lead",
+	"00T": "Task",
+	"00U": "Event",
+	"00X": "EmailTemplate",
+	"00a": "CaseComment",
+	"00b": "WebLink",
+	"00e": "Profile",
+	"00h": "Layout",
+	"00k": "OpportunityLineItem",
+	"00l": "Folder",
+	"00v": "CampaignMember",
+	"015": "Document",
+	"01I": "CustomObject",
+	"01Z": "Dashboard",
+	"01p": "ApexClass",
+	"01q": "ApexTrigger",
+	"01s": "Pricebook2",
+	"01t": "Product2",
+	"01u": "PricebookEntry",
+	"02i": "Asset",
+	"02s": "EmailMessage",
+	"066": "ApexPage",
+	"068": "ContentVersion",
+	"069": "ContentDocument",
+	"099": "ApexComponent",
+	"0PS": "PermissionSet",
+	"0Q0": "Quote",
+	"0QL": "QuoteLineItem",
+	"0WO": "WorkOrder",
+	"500": "Case",
+	"501": "Solution",
+	"701": "Campaign",
+	"800": "Contract",
+	"801": "Order",
+	"802": "OrderItem",
+}
+
+// KeyPrefixRegistry maps the three byte KeyPrefix of a SalesforceID to the
+// name of the sObject it identifies. Key prefixes are case-sensitive. A
+// KeyPrefixRegistry is safe for concurrent use.
+type KeyPrefixRegistry struct {
+	mu       sync.RWMutex
+	prefixes map[string]string
+}
+
+// DefaultKeyPrefixes is the registry used by [SalesforceID.ObjectType]. It
+// starts with the standard objects and custom objects can be added to it with
+// [RegisterKeyPrefix].
+var DefaultKeyPrefixes = NewStandardKeyPrefixRegistry()
+
+// NewKeyPrefixRegistry creates an empty KeyPrefixRegistry.
+func NewKeyPrefixRegistry() *KeyPrefixRegistry {
+	return &KeyPrefixRegistry{prefixes: map[string]string{}}
+}
+
+// NewStandardKeyPrefixRegistry creates a KeyPrefixRegistry populated with
+// the key prefixes of standard objects.
+func NewStandardKeyPrefixRegistry() *KeyPrefixRegistry {
+	r := NewKeyPrefixRegistry()
+	for prefix, name := range standardKeyPrefixes {
+		r.prefixes[prefix] = name
+	}
+	return r
+}
+
+// Register associates prefix with the sObject name. Registering a prefix
+// that is already present replaces its name. This returns
+// [ErrInvalidKeyPrefix] if prefix is not 3 bytes long.
+func (r *KeyPrefixRegistry) Register(prefix, name string) error {
+	if len(prefix) != 3 {
+		return ErrInvalidKeyPrefix
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.prefixes[prefix] = name
+	return nil
+}
+
+// Lookup returns the sObject name for prefix. This returns an
+// [*UnknownKeyPrefixError] if the prefix has not been registered.
+func (r *KeyPrefixRegistry) Lookup(prefix []byte) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name, ok := r.prefixes[string(prefix)]
+	if !ok {
+		return "", &UnknownKeyPrefixError{Prefix: string(prefix)}
+	}
+	return name, nil
+}
+
+// ObjectType returns the name of the sObject identified by the KeyPrefix of
+// s.
+func (r *KeyPrefixRegistry) ObjectType(s *SalesforceID) (string, error) {
+	return r.Lookup(s.KeyPrefix)
+}
+
+// RegisterKeyPrefix adds a key prefix to [DefaultKeyPrefixes].
+func RegisterKeyPrefix(prefix, name string) error {
+	return DefaultKeyPrefixes.Register(prefix, name)
+}
+
+// ObjectType returns the name of the sObject identified by the KeyPrefix
+// using [DefaultKeyPrefixes].
+func (s *SalesforceID) ObjectType() (string, error) {
+	return DefaultKeyPrefixes.ObjectType(s)
+}
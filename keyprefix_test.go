@@ -0,0 +1,102 @@
+package salesforceid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sigmavirus24/salesforceid"
+)
+
+func TestSalesforceID_ObjectType(t *testing.T) {
+	// Register custom prefixes on a copy so DefaultKeyPrefixes is left
+	// unchanged for other tests.
+	r := salesforceid.NewStandardKeyPrefixRegistry()
+	if err := r.Register("a0X", "Invoice__c"); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if got, err := mustNew(t, "001000000000000AAA").ObjectType(); err != nil || got != "Account" {
+		t.Errorf("ObjectType() = %s, %v, want Account", got, err)
+	}
+	if _, err := mustNew(t, "a0X000000000062").ObjectType(); !errors.Is(err, salesforceid.ErrUnknownKeyPrefix) {
+		t.Errorf("expected err to match %q but got %q", salesforceid.ErrUnknownKeyPrefix, err)
+	}
+	testCases := []struct {
+		sfid       string
+		want       string
+		wantErr    bool
+		wantPrefix string
+	}{
+		{"001000000000000AAA", "Account", false, ""},
+		{"003D0000001aH2AIAU", "Contact", false, ""},
+		{"00D000000000062EAA", "Organization", false, ""},
+		{"a0X000000000062", "Invoice__c", false, ""},
+		{"a0Y000000000062", "", true, "a0Y"},
+		{"00d000000000062", "", true, "00d"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.sfid, func(t *testing.T) {
+			id, err := salesforceid.New(tc.sfid)
+			if err != nil {
+				t.Fatalf("New(%q) error = %v", tc.sfid, err)
+			}
+			got, err := r.ObjectType(id)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ObjectType() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				if !errors.Is(err, salesforceid.ErrUnknownKeyPrefix) {
+					t.Errorf("expected err to match %q but got %q", salesforceid.ErrUnknownKeyPrefix, err)
+				}
+				var unknown *salesforceid.UnknownKeyPrefixError
+				if !errors.As(err, &unknown) || unknown.Prefix != tc.wantPrefix {
+					t.Errorf("expected UnknownKeyPrefixError for %q but got %#v", tc.wantPrefix, err)
+				}
+				return
+			}
+			if got != tc.want {
+				t.Errorf("ObjectType() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKeyPrefixRegistry_Register(t *testing.T) {
+	testCases := []struct {
+		prefix  string
+		name    string
+		wantErr error
+	}{
+		{"a0X", "Invoice__c", nil},
+		{"001", "NotAnAccount__c", nil},
+		{"a0", "Short__c", salesforceid.ErrInvalidKeyPrefix},
+		{"a0XX", "Long__c", salesforceid.ErrInvalidKeyPrefix},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.prefix, func(t *testing.T) {
+			r := salesforceid.NewKeyPrefixRegistry()
+			err := r.Register(tc.prefix, tc.name)
+			if err != tc.wantErr {
+				t.Fatalf("Register(%q, %q) error = %v, want %v", tc.prefix, tc.name, err, tc.wantErr)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			got, err := r.Lookup([]byte(tc.prefix))
+			if err != nil {
+				t.Fatalf("Lookup(%q) error = %v", tc.prefix, err)
+			}
+			if got != tc.name {
+				t.Errorf("Lookup(%q) = %s, want %s", tc.prefix, got, tc.name)
+			}
+		})
+	}
+}
+
+func TestNewKeyPrefixRegistry(t *testing.T) {
+	r := salesforceid.NewKeyPrefixRegistry()
+	if _, err := r.Lookup([]byte("001")); !errors.Is(err, salesforceid.ErrUnknownKeyPrefix) {
+		t.Errorf("expected empty registry to not know 001 but got %v", err)
+	}
+}
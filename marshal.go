@@ -0,0 +1,51 @@
+package salesforceid
+
+import "encoding/json"
+
+// MarshalText implements [encoding.TextMarshaler]. It always produces the
+// 18 character identifier. The zero value produces empty text.
+func (s SalesforceID) MarshalText() ([]byte, error) {
+	if s.id == nil {
+		return []byte{}, nil
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler]. It accepts 15 or 18
+// character identifiers and detects their edition as with [New], ignoring any
+// Edition s already has. Empty text sets s to the zero value so that
+// [SalesforceID.MarshalText] round-trips.
+func (s *SalesforceID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*s = SalesforceID{}
+		return nil
+	}
+	parsed, err := Parse(string(text), AutoIdentifierEdition)
+	if err != nil {
+		return err
+	}
+	*s = *parsed
+	return nil
+}
+
+// MarshalJSON implements [json.Marshaler]. It produces the 18 character
+// identifier as a JSON string or `null` for the zero value.
+func (s SalesforceID) MarshalJSON() ([]byte, error) {
+	if s.id == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON implements [json.Unmarshaler]. It accepts a JSON string
+// containing a 15 or 18 character identifier. `null` leaves s unchanged.
+func (s *SalesforceID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return s.UnmarshalText([]byte(text))
+}
@@ -0,0 +1,140 @@
+package salesforceid_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sigmavirus24/salesforceid"
+)
+
+type record struct {
+	ID       salesforceid.SalesforceID  `json:"id"`
+	ParentID *salesforceid.SalesforceID `json:"parentId"`
+}
+
+func TestSalesforceID_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		name         string
+		input        string
+		wantID       string
+		wantParentID string
+		wantErr      error
+	}{
+		{"18 characters", `{"id": "00D000000000062EAA", "parentId": "001000000000000AAA"}`, "00D000000000062EAA", "001000000000000AAA", nil},
+		{"15 characters", `{"id": "00D000000000062", "parentId": "001000000000000"}`, "00D000000000062EAA", "001000000000000AAA", nil},
+		{"corrects casing", `{"id": "00d000000000062eaa", "parentId": null}`, "00D000000000062EAA", "", nil},
+		{"null parent", `{"id": "00D000000000062", "parentId": null}`, "00D000000000062EAA", "", nil},
+		{"post summer 23", `{"id": "0011Ab000000062QAA"}`, "0011Ab000000062QAA", "", nil},
+		{"invalid length", `{"id": "00D00000000006"}`, "", "", salesforceid.ErrInvalidLengthSFID},
+		{"invalid check bytes", `{"id": "001000000000062EAA"}`, "", "", salesforceid.ErrInvalidSFID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got record
+			err := json.Unmarshal([]byte(tc.input), &got)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Errorf("expected err %q but got %q", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("didn't expect an error but got %q", err)
+			}
+			if got.ID.String() != tc.wantID {
+				t.Errorf("wanted id %s, got %s", tc.wantID, got.ID.String())
+			}
+			if tc.wantParentID == "" {
+				if got.ParentID != nil {
+					t.Errorf("wanted nil parent id, got %s", got.ParentID)
+				}
+				return
+			}
+			if got.ParentID == nil || got.ParentID.String() != tc.wantParentID {
+				t.Errorf("wanted parent id %s, got %v", tc.wantParentID, got.ParentID)
+			}
+		})
+	}
+}
+
+func TestSalesforceID_MarshalJSON(t *testing.T) {
+	id, _ := salesforceid.New("00d000000000062eaa")
+	parentID, _ := salesforceid.New("001000000000000")
+	testCases := []struct {
+		name  string
+		input record
+		want  string
+	}{
+		{"all set", record{ID: *id, ParentID: parentID}, `{"id":"00D000000000062EAA","parentId":"001000000000000AAA"}`},
+		{"nil pointer", record{ID: *id}, `{"id":"00D000000000062EAA","parentId":null}`},
+		{"zero value", record{}, `{"id":null,"parentId":null}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := json.Marshal(tc.input)
+			if err != nil {
+				t.Fatalf("didn't expect an error but got %q", err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("wanted %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSalesforceID_UnmarshalText(t *testing.T) {
+	var id salesforceid.SalesforceID
+	if err := id.UnmarshalText([]byte("0011Ab000000062")); err != nil {
+		t.Fatalf("didn't expect an error but got %q", err)
+	}
+	if id.Edition != salesforceid.PostSummer23IdentifierEdition || string(id.PodIdentifier) != "1Ab" {
+		t.Errorf("wanted edition to be detected, got edition %d and pod identifier %s", id.Edition, id.PodIdentifier)
+	}
+	text, err := id.MarshalText()
+	if err != nil {
+		t.Fatalf("didn't expect an error but got %q", err)
+	}
+	if string(text) != "0011Ab000000062QAA" {
+		t.Errorf("wanted 0011Ab000000062QAA, got %s", text)
+	}
+}
+
+func TestSalesforceID_UnmarshalJSON_reused(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`"00D000000000062" "0011Ab000000062" "00130000000abCd"`))
+	want := []string{"00D000000000062EAA", "0011Ab000000062QAA", "00130000000abCdAAI"}
+	var id salesforceid.SalesforceID
+	for _, w := range want {
+		if err := dec.Decode(&id); err != nil {
+			t.Fatalf("didn't expect an error but got %q", err)
+		}
+		if id.String() != w {
+			t.Errorf("wanted %s, got %s", w, id.String())
+		}
+	}
+}
+
+func TestSalesforceID_UnmarshalText_zeroValue(t *testing.T) {
+	text, err := salesforceid.SalesforceID{}.MarshalText()
+	if err != nil {
+		t.Fatalf("didn't expect an error but got %q", err)
+	}
+	id, _ := salesforceid.New("00D000000000062")
+	if err := id.UnmarshalText(text); err != nil {
+		t.Fatalf("didn't expect an error but got %q", err)
+	}
+	if diff := cmp.Diff(&salesforceid.SalesforceID{}, id, compareFields); diff != "" {
+		t.Errorf("wanted the zero value, diff = %s", diff)
+	}
+	again, err := id.MarshalText()
+	if err != nil {
+		t.Fatalf("didn't expect an error but got %q", err)
+	}
+	if string(again) != "" {
+		t.Errorf("wanted empty text, got %s", again)
+	}
+}
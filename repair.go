@@ -0,0 +1,157 @@
+package salesforceid
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxRepairCandidates is the most identifiers [Repair] will consider for an
+// identifier that lost digits to scientific notation.
+const maxRepairCandidates = 1000
+
+// RepairKind identifies damage that [Repair] fixed.
+type RepairKind uint8
+
+const (
+	// WhitespaceRepair removes whitespace and the leading apostrophe
+	// spreadsheets use to mark text.
+	WhitespaceRepair RepairKind = iota + 1
+	// LeadingZerosRepair restores the leading zeros removed when an
+	// identifier made of digits was treated as a number.
+	LeadingZerosRepair
+	// ScientificNotationRepair converts a number written in scientific
+	// notation, e.g., `1.00000000006E+12`, back to its digits.
+	ScientificNotationRepair
+)
+
+// String returns a short name for the repair.
+func (k RepairKind) String() string {
+	switch k {
+	case WhitespaceRepair:
+		return "whitespace"
+	case LeadingZerosRepair:
+		return "leading zeros"
+	case ScientificNotationRepair:
+		return "scientific notation"
+	default:
+		return fmt.Sprintf("RepairKind(%d)", k)
+	}
+}
+
+// RepairResult describes the identifiers [Repair] reconstructed from damaged
+// input.
+type RepairResult struct {
+	Input string
+	// Candidates are the identifiers Input could have been
+	Candidates []*SalesforceID
+	// Repairs lists the damage that was fixed in the order it was fixed
+	Repairs []RepairKind
+	// Certain is true if Input only needed whitespace removed or there is a
+	// single candidate with a known KeyPrefix and no digits were lost
+	Certain bool
+}
+
+// Repair reconstructs the identifiers that input could have been before it
+// was damaged by a spreadsheet. Spreadsheets pad or trim whitespace, treat
+// identifiers made of digits, e.g., `001000000000062`, as numbers which
+// removes their leading zeros, and may show large numbers in scientific
+// notation which can lose digits. Candidates are parsed with edition.
+//
+// This returns [ErrUnrepairable] if input could not be repaired or would have
+// more than 1000 candidates.
+func Repair(input string, edition IdentifierEdition) (RepairResult, error) {
+	result := RepairResult{Input: input}
+	s := strings.TrimSpace(input)
+	s = strings.TrimSpace(strings.TrimPrefix(s, "'"))
+	if s != input {
+		result.Repairs = append(result.Repairs, WhitespaceRepair)
+	}
+
+	if len(s) == 15 || len(s) == 18 {
+		if id, err := Parse(s, edition); err == nil {
+			result.Candidates = []*SalesforceID{id}
+			result.Certain = true
+			return result, nil
+		}
+	}
+
+	lo, hi, scientific, ok := parseNumber(s)
+	if !ok {
+		return RepairResult{}, fmt.Errorf("%w: %q", ErrUnrepairable, input)
+	}
+	if scientific {
+		result.Repairs = append(result.Repairs, ScientificNotationRepair)
+	}
+	if hi >= 1e15 || hi-lo >= maxRepairCandidates {
+		return RepairResult{}, fmt.Errorf("%w: %q", ErrUnrepairable, input)
+	}
+	if hi < 1e14 {
+		result.Repairs = append(result.Repairs, LeadingZerosRepair)
+	}
+
+	for v := lo; v <= hi; v++ {
+		id, err := Parse(fmt.Sprintf("%015d", v), edition)
+		if err != nil {
+			continue
+		}
+		result.Candidates = append(result.Candidates, id)
+	}
+	if len(result.Candidates) == 0 {
+		return RepairResult{}, fmt.Errorf("%w: %q", ErrUnrepairable, input)
+	}
+	result.Certain = lo == hi && hasKnownPrefix(result.Candidates[0])
+	return result, nil
+}
+
+// parseNumber parses s as a non-negative integer written as digits, with a
+// fraction of zeros, or in scientific notation. It returns the range of
+// integers that are shown as s after rounding and whether s used scientific
+// notation.
+func parseNumber(s string) (lo, hi uint64, scientific, ok bool) {
+	mantissa, exponent := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(strings.TrimPrefix(s[i+1:], "+"))
+		if err != nil {
+			return 0, 0, false, false
+		}
+		mantissa, exponent, scientific = s[:i], e, true
+	}
+	whole, fraction, _ := strings.Cut(mantissa, ".")
+	digits := whole + fraction
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return 0, 0, false, false
+	}
+	// shift is the power of 10 of the last digit shown.
+	shift := exponent - len(fraction)
+	for shift < 0 && strings.HasSuffix(digits, "0") {
+		digits = digits[:len(digits)-1]
+		shift++
+	}
+	if shift < 0 || shift > 15 {
+		return 0, 0, false, false
+	}
+	v, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return 0, 0, false, false
+	}
+	pow := uint64(1)
+	for range shift {
+		pow *= 10
+	}
+	if v > (1<<64-1)/pow {
+		return 0, 0, false, false
+	}
+	v *= pow
+	if !scientific || shift == 0 {
+		return v, v, scientific, true
+	}
+	// The digits shown were rounded half away from zero.
+	half := pow / 2
+	return v - min(v, half), v + half - 1, true, true
+}
+
+func hasKnownPrefix(id *SalesforceID) bool {
+	_, err := id.ObjectType()
+	return err == nil
+}
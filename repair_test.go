@@ -0,0 +1,111 @@
+package salesforceid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sigmavirus24/salesforceid"
+)
+
+func TestRepair(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          string
+		wantCandidates []string
+		wantRepairs    []salesforceid.RepairKind
+		wantCertain    bool
+		wantErr        error
+	}{
+		{
+			name:           "undamaged",
+			input:          "00D000000000062",
+			wantCandidates: []string{"00D000000000062EAA"},
+			wantCertain:    true,
+		},
+		{
+			name:           "whitespace",
+			input:          " 00D000000000062EAA \t",
+			wantCandidates: []string{"00D000000000062EAA"},
+			wantRepairs:    []salesforceid.RepairKind{salesforceid.WhitespaceRepair},
+			wantCertain:    true,
+		},
+		{
+			name:           "text marker",
+			input:          "'001000000000062",
+			wantCandidates: []string{"001000000000062AAA"},
+			wantRepairs:    []salesforceid.RepairKind{salesforceid.WhitespaceRepair},
+			wantCertain:    true,
+		},
+		{
+			name:           "leading zeros",
+			input:          "1000000000062",
+			wantCandidates: []string{"001000000000062AAA"},
+			wantRepairs:    []salesforceid.RepairKind{salesforceid.LeadingZerosRepair},
+			wantCertain:    true,
+		},
+		{
+			name:           "leading zeros with unknown key prefix",
+			input:          "40000000000062",
+			wantCandidates: []string{"040000000000062AAA"},
+			wantRepairs:    []salesforceid.RepairKind{salesforceid.LeadingZerosRepair},
+		},
+		{
+			name:           "decimal",
+			input:          "500000000000062.00",
+			wantCandidates: []string{"500000000000062AAA"},
+			wantCertain:    true,
+		},
+		{
+			name:           "exact scientific notation",
+			input:          "1.000000000062E+12",
+			wantCandidates: []string{"001000000000062AAA"},
+			wantRepairs:    []salesforceid.RepairKind{salesforceid.ScientificNotationRepair, salesforceid.LeadingZerosRepair},
+			wantCertain:    true,
+		},
+		{
+			name:  "rounded scientific notation",
+			input: "1.00000000006e12",
+			wantCandidates: []string{
+				"001000000000055AAA", "001000000000056AAA", "001000000000057AAA", "001000000000058AAA", "001000000000059AAA",
+				"001000000000060AAA", "001000000000061AAA", "001000000000062AAA", "001000000000063AAA", "001000000000064AAA",
+			},
+			wantRepairs: []salesforceid.RepairKind{salesforceid.ScientificNotationRepair, salesforceid.LeadingZerosRepair},
+		},
+		{
+			name:  "invalid candidates are skipped",
+			input: "4.00000000000E+12",
+			wantCandidates: []string{
+				"004000000000000AAA", "004000000000001AAA", "004000000000002AAA", "004000000000003AAA", "004000000000004AAA",
+			},
+			wantRepairs: []salesforceid.RepairKind{salesforceid.ScientificNotationRepair, salesforceid.LeadingZerosRepair},
+		},
+		{"too many digits lost", "1E+12", nil, nil, false, salesforceid.ErrUnrepairable},
+		{"too large", "1.0E+15", nil, nil, false, salesforceid.ErrUnrepairable},
+		{"not a number", "00D00000000006", nil, nil, false, salesforceid.ErrUnrepairable},
+		{"negative", "-1000000000062", nil, nil, false, salesforceid.ErrUnrepairable},
+		{"fraction", "100000000062.5", nil, nil, false, salesforceid.ErrUnrepairable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := salesforceid.Repair(tc.input, salesforceid.PreSummer23IdentifierEdition)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected err %q but got %q", tc.wantErr, err)
+			}
+			var candidates []string
+			for _, id := range got.Candidates {
+				candidates = append(candidates, id.String())
+			}
+			if diff := cmp.Diff(tc.wantCandidates, candidates); diff != "" {
+				t.Errorf("Repair(%q) candidates mismatch (-want +got):\n%s", tc.input, diff)
+			}
+			if diff := cmp.Diff(tc.wantRepairs, got.Repairs); diff != "" {
+				t.Errorf("Repair(%q) repairs mismatch (-want +got):\n%s", tc.input, diff)
+			}
+			if got.Certain != tc.wantCertain {
+				t.Errorf("Repair(%q) certain = %t, want %t", tc.input, got.Certain, tc.wantCertain)
+			}
+		})
+	}
+}
@@ -13,12 +13,31 @@ type Format uint8
 // expand the identifier to three characters.
 type IdentifierEdition uint8
 
+// Confidence reports how certain [ParseAuto] is of the IdentifierEdition it
+// detected.
+type Confidence uint8
+
 const (
 	FifteenCharacterFormat Format = iota + 1
 	EighteenCharacterFormat
 
 	PreSummer23IdentifierEdition IdentifierEdition = iota + 1
 	PostSummer23IdentifierEdition
+	// AutoIdentifierEdition asks [Parse] to detect the edition. See
+	// [ParseAuto] for how the edition is chosen.
+	AutoIdentifierEdition
+)
+
+const (
+	// LowConfidence means nothing in the identifier distinguishes the
+	// editions and [PreSummer23IdentifierEdition] was assumed.
+	LowConfidence Confidence = iota + 1
+	// MediumConfidence means the pod identifier matched a known instance
+	// for the detected edition.
+	MediumConfidence
+	// HighConfidence means the identifier can only be valid in the detected
+	// edition.
+	HighConfidence
 )
 
 // SalesforceID stores and manages the Salesforce Identifier and its
@@ -37,17 +56,112 @@ type SalesforceID struct {
 	Edition           IdentifierEdition
 }
 
-// New generates a SalesforceID for usage. It assumes the edition to be
-// [PreSummer23IdentifierEdition] for backwards compatibility.
+// New generates a SalesforceID for usage. It detects the edition as with
+// [ParseAuto] so, for backwards compatibility, identifiers whose sixth byte is
+// `0` are [PreSummer23IdentifierEdition].
 func New(id string) (*SalesforceID, error) {
-	return Parse(id, PreSummer23IdentifierEdition)
+	return Parse(id, AutoIdentifierEdition)
 }
 
+// Parse generates a SalesforceID using the given edition to split the pod
+// identifier from the reserved bytes. Use [AutoIdentifierEdition] to detect
+// the edition.
 func Parse(id string, edition IdentifierEdition) (*SalesforceID, error) {
 	idBytes, err := prepareID(id)
 	if err != nil {
 		return nil, err
 	}
+	if edition == AutoIdentifierEdition {
+		edition = canonicalEdition(idBytes)
+	}
+	s, err := fromBytes(idBytes, edition)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFields(idBytes, edition); err != nil {
+		err.Input = id
+		return nil, err
+	}
+	return s, nil
+}
+
+// ParseOptions configures [ParseWithOptions].
+type ParseOptions struct {
+	// Edition is used to split the pod identifier from the reserved bytes.
+	// If Edition is 0, it is detected as with [New].
+	Edition IdentifierEdition
+	// Sanitize converts the identifier to ASCII with [Sanitize] before it is
+	// parsed.
+	Sanitize bool
+}
+
+// ParseWithOptions generates a SalesforceID as configured by opts. It also
+// returns the changes made when opts.Sanitize is set, even if the sanitized
+// identifier cannot be parsed. A [*ParseError] describes the sanitized
+// identifier.
+func ParseWithOptions(id string, opts ParseOptions) (*SalesforceID, []Substitution, error) {
+	var substitutions []Substitution
+	if opts.Sanitize {
+		id, substitutions = Sanitize(id)
+	}
+	edition := opts.Edition
+	if edition == 0 {
+		edition = AutoIdentifierEdition
+	}
+	s, err := Parse(id, edition)
+	return s, substitutions, err
+}
+
+// ParseAuto generates a SalesforceID after detecting its edition and reports
+// how confident the detection is. The edition is detected by:
+//
+//  1. If the sixth byte is not `0` it cannot be reserved so the identifier is
+//     [PostSummer23IdentifierEdition] with [HighConfidence].
+//  2. If the 2 byte pod identifier is registered in [DefaultInstances] the
+//     identifier is [PreSummer23IdentifierEdition] with [MediumConfidence].
+//  3. Otherwise the identifier is assumed to be [PreSummer23IdentifierEdition]
+//     with [LowConfidence].
+func ParseAuto(id string) (*SalesforceID, Confidence, error) {
+	idBytes, err := prepareID(id)
+	if err != nil {
+		return nil, 0, err
+	}
+	edition, confidence := detectEdition(idBytes)
+	s, err := fromBytes(idBytes, edition)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := checkFields(idBytes, edition); err != nil {
+		err.Input = id
+		return nil, 0, err
+	}
+	return s, confidence, nil
+}
+
+// canonicalEdition is the edition [detectEdition] chooses for id without
+// taking the lock of [DefaultInstances]. It is also the edition of an
+// identifier whose edition was not kept, e.g., in an ID or an IDSet.
+// Identifiers whose sixth byte is `0` are valid in either edition so one is
+// chosen to keep them comparable.
+func canonicalEdition(id []byte) IdentifierEdition {
+	if id[5] != '0' {
+		return PostSummer23IdentifierEdition
+	}
+	return PreSummer23IdentifierEdition
+}
+
+func detectEdition(idBytes []byte) (IdentifierEdition, Confidence) {
+	switch {
+	case idBytes[5] != '0':
+		return PostSummer23IdentifierEdition, HighConfidence
+	case DefaultInstances.has(idBytes[3:5]):
+		return PreSummer23IdentifierEdition, MediumConfidence
+	default:
+		return PreSummer23IdentifierEdition, LowConfidence
+	}
+}
+
+func fromBytes(idBytes []byte, edition IdentifierEdition) (*SalesforceID, error) {
 	switch edition {
 	case PreSummer23IdentifierEdition:
 		return &SalesforceID{
@@ -88,7 +202,7 @@ func (s *SalesforceID) Format(f Format) string {
 }
 
 // Add a value to the numeric identifier and ensure the resulting identifier
-// is valid.
+// is valid. The result has the same Edition as s.
 func (s *SalesforceID) Add(i uint64) (*SalesforceID, error) {
 	encoded, err := addToID(s.NumericIdentifier, i)
 	if err != nil {
@@ -97,11 +211,11 @@ func (s *SalesforceID) Add(i uint64) (*SalesforceID, error) {
 	newID := make([]byte, 15)
 	copy(newID, s.id[:7])
 	copy(newID[7:15], encoded)
-	return New(string(newID))
+	return Parse(string(newID), s.Edition)
 }
 
 // Subtract a value from the numeric identifier and ensure the resulting
-// identifier is valid.
+// identifier is valid. The result has the same Edition as s.
 func (s *SalesforceID) Subtract(i uint64) (*SalesforceID, error) {
 	encoded, err := subtractFromID(s.NumericIdentifier, i)
 	if err != nil {
@@ -110,5 +224,5 @@ func (s *SalesforceID) Subtract(i uint64) (*SalesforceID, error) {
 	newID := make([]byte, 15)
 	copy(newID, s.id[:7])
 	copy(newID[7:15], encoded)
-	return New(string(newID))
+	return Parse(string(newID), s.Edition)
 }
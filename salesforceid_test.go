@@ -1,14 +1,40 @@
 package salesforceid_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"github.com/sigmavirus24/salesforceid"
 )
 
+// parsedFields holds the exported fields of a SalesforceID.
+type parsedFields struct {
+	KeyPrefix         string
+	PodIdentifier     string
+	Reserved          string
+	NumericIdentifier string
+	Suffix            string
+	Edition           salesforceid.IdentifierEdition
+}
+
+// compareFields makes cmp compare the exported fields of a SalesforceID
+// instead of using SalesforceID.Equal which only compares the identifier.
+var compareFields = cmp.Transformer("fields", func(s *salesforceid.SalesforceID) *parsedFields {
+	if s == nil {
+		return nil
+	}
+	return &parsedFields{
+		KeyPrefix:         string(s.KeyPrefix),
+		PodIdentifier:     string(s.PodIdentifier),
+		Reserved:          string(s.Reserved),
+		NumericIdentifier: string(s.NumericIdentifier),
+		Suffix:            string(s.Suffix),
+		Edition:           s.Edition,
+	}
+})
+
 func TestNew(t *testing.T) {
 	testCases := []struct {
 		sfid        string
@@ -24,20 +50,32 @@ func TestNew(t *testing.T) {
 		{"0a3D0000001aH2A", "0a3D0000001aH2AIAU", false, nil},
 		{"0A3D0000001aH2A", "0A3D0000001aH2AKAU", false, nil},
 		{"0a3d0000001ah2a", "0a3d0000001ah2aAAA", false, nil},
+		{"0011Ab000000062", "0011Ab000000062QAA", false, nil},
+		{"0011Ab000000062QAA", "0011Ab000000062QAA", false, nil},
 		{"000000000000000", "000000000000000AAA", false, nil},
-		{"999999999999999", "999999999999999AAA", false, nil},
-		{"aaaaaaaaaaaaaaa", "aaaaaaaaaaaaaaaAAA", false, nil},
-		{"zzzzzzzzzzzzzzz", "zzzzzzzzzzzzzzzAAA", false, nil},
-		{"AAAAAAAAAAAAAAA", "AAAAAAAAAAAAAAA555", false, nil},
-		{"ZZZZZZZZZZZZZZZ", "ZZZZZZZZZZZZZZZ555", false, nil},
+		{"999999999999999", "", true, salesforceid.ErrInvalidReserved},
+		{"999990099999999", "999990099999999AAA", false, nil},
+		{"aaaaaaaaaaaaaaa", "", true, salesforceid.ErrInvalidReserved},
+		{"aaaaa00aaaaaaaa", "aaaaa00aaaaaaaaAAA", false, nil},
+		{"zzzzzzzzzzzzzzz", "", true, salesforceid.ErrInvalidReserved},
+		{"zzzzz00zzzzzzzz", "zzzzz00zzzzzzzzAAA", false, nil},
+		{"AAAAAAAAAAAAAAA", "", true, salesforceid.ErrInvalidReserved},
+		{"AAAAA00AAAAAAAA", "AAAAA00AAAAAAAA525", false, nil},
+		{"ZZZZZZZZZZZZZZZ", "", true, salesforceid.ErrInvalidReserved},
+		{"ZZZZZ00ZZZZZZZZ", "ZZZZZ00ZZZZZZZZ525", false, nil},
 		{"ZZZZZZZZZZZZZZ", "", true, salesforceid.ErrInvalidLengthSFID},   // 14 char sfid
 		{"ZZZZZZZZZZZZZZZZ", "", true, salesforceid.ErrInvalidLengthSFID}, // 16 char sfid
 		{"001000000000062EAA", "", true, salesforceid.ErrInvalidSFID},     // 001 should be 00<cap>
-		{"aaaaaaaaaaaaaaa555", "AAAAAAAAAAAAAAA555", false, nil},
-		{"ZZZZZZZZZZZZZZZ555", "ZZZZZZZZZZZZZZZ555", false, nil},
-		{"zzzzzzzzzzzzzzz555", "ZZZZZZZZZZZZZZZ555", false, nil},
-		{"ZzZzZzZzZZzZZzzAAA", "zzzzzzzzzzzzzzzAAA", false, nil},
-		{"ZZZZZZZZZZZZZZZAAA", "zzzzzzzzzzzzzzzAAA", false, nil},
+		{"aaaaaaaaaaaaaaa555", "", true, salesforceid.ErrInvalidReserved},
+		{"aaaaa00aaaaaaaa525", "AAAAA00AAAAAAAA525", false, nil},
+		{"ZZZZZZZZZZZZZZZ555", "", true, salesforceid.ErrInvalidReserved},
+		{"ZZZZZ00ZZZZZZZZ525", "ZZZZZ00ZZZZZZZZ525", false, nil},
+		{"zzzzzzzzzzzzzzz555", "", true, salesforceid.ErrInvalidReserved},
+		{"zzzzz00zzzzzzzz525", "ZZZZZ00ZZZZZZZZ525", false, nil},
+		{"ZzZzZzZzZZzZZzzAAA", "", true, salesforceid.ErrInvalidReserved},
+		{"ZzZzZ00zZZzZZzzAAA", "zzzzz00zzzzzzzzAAA", false, nil},
+		{"ZZZZZZZZZZZZZZZAAA", "", true, salesforceid.ErrInvalidReserved},
+		{"ZZZZZ00ZZZZZZZZAAA", "zzzzz00zzzzzzzzAAA", false, nil},
 	}
 
 	for _, tc := range testCases {
@@ -47,7 +85,7 @@ func TestNew(t *testing.T) {
 			if tc.shouldErr && err == nil {
 				t.Errorf("expected error but didn't get an error")
 			}
-			if tc.shouldErr && err != tc.expectedErr {
+			if tc.shouldErr && !errors.Is(err, tc.expectedErr) {
 				t.Errorf("expected err %q, got err %q", tc.expectedErr, err)
 			}
 			if !tc.shouldErr && err != nil {
@@ -63,6 +101,33 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNew_detectsEdition(t *testing.T) {
+	testCases := []struct {
+		sfid        string
+		wantEdition salesforceid.IdentifierEdition
+		wantPod     string
+	}{
+		{"00130000000abCd", salesforceid.PreSummer23IdentifierEdition, "30"},
+		{"0011Ab000000062QAA", salesforceid.PostSummer23IdentifierEdition, "1Ab"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.sfid, func(t *testing.T) {
+			id, err := salesforceid.New(tc.sfid)
+			if err != nil {
+				t.Fatalf("didn't expect an error but got %q", err)
+			}
+			if id.Edition != tc.wantEdition || string(id.PodIdentifier) != tc.wantPod {
+				t.Errorf("New(%q) = edition %d and pod identifier %s, want edition %d and pod identifier %s", tc.sfid, id.Edition, id.PodIdentifier, tc.wantEdition, tc.wantPod)
+			}
+		})
+	}
+
+	if _, err := salesforceid.Parse("0011Ab000000062QAA", salesforceid.PreSummer23IdentifierEdition); !errors.Is(err, salesforceid.ErrInvalidReserved) {
+		t.Errorf("expected err %q but got %q", salesforceid.ErrInvalidReserved, err)
+	}
+}
+
 func BenchmarkNew(b *testing.B) {
 	b.Run("15 char sfid", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
@@ -77,7 +142,7 @@ func BenchmarkNew(b *testing.B) {
 		})
 		b.Run("everything needs correction", func(b *testing.B) {
 			for i := 0; i < b.N; i++ {
-				_, _ = salesforceid.New("zzzzzzzzzzzzzzz555")
+				_, _ = salesforceid.New("zzzzz00zzzzzzzz525")
 			}
 		})
 	})
@@ -106,7 +171,7 @@ func TestSalesforceID_Add(t *testing.T) {
 			if tc.shouldErr && err == nil {
 				t.Errorf("expected error but got nil")
 			}
-			if tc.shouldErr && err != tc.expectedErr {
+			if tc.shouldErr && !errors.Is(err, tc.expectedErr) {
 				t.Errorf("expected err %q but got %q", tc.expectedErr, err)
 			}
 			if !tc.shouldErr && err != nil {
@@ -128,7 +193,7 @@ func TestSalesforceID_Subtract(t *testing.T) {
 		expectedErr error
 	}{
 		{"001000000000000AAA", 1, "", true, salesforceid.ErrInvalidSubtraction},
-		{"001000zzzzzzzzzAAA", 1, "001000zzzzzzzzyAAA", false, nil},
+		{"0010000zzzzzzzzAAA", 1, "0010000zzzzzzzyAAA", false, nil},
 		{"001000000000010AAA", 1, "00100000000000zAAA", false, nil},
 		{"00100000000000aAAA", 1, "00100000000000ZAAQ", false, nil},
 	}
@@ -141,7 +206,7 @@ func TestSalesforceID_Subtract(t *testing.T) {
 			if tc.shouldErr && err == nil {
 				t.Errorf("expected error but got nil")
 			}
-			if tc.shouldErr && err != tc.expectedErr {
+			if tc.shouldErr && !errors.Is(err, tc.expectedErr) {
 				t.Errorf("expected err %q but got %q", tc.expectedErr, err)
 			}
 			if !tc.shouldErr && err != nil {
@@ -193,7 +258,7 @@ func TestSalesforceID_Subtract(t *testing.T) {
 // 			if tc.shouldErr && err == nil {
 // 				t.Errorf("expected error but didn't get an error")
 // 			}
-// 			if tc.shouldErr && err != tc.expectedErr {
+// 			if tc.shouldErr && !errors.Is(err, tc.expectedErr) {
 // 				t.Errorf("expected err %q, got err %q", tc.expectedErr, err)
 // 			}
 // 			if !tc.shouldErr && err != nil {
@@ -380,64 +445,70 @@ func TestParse(t *testing.T) {
 		},
 		{
 			name:    "generates suffix (all nines)",
-			args:    args{id: "999999999999999", edition: salesforceid.PostSummer23IdentifierEdition},
-			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("999"), PodIdentifier: []byte("999"), Reserved: []byte("9"), NumericIdentifier: []byte("99999999"), Suffix: []byte("AAA"), Edition: salesforceid.PostSummer23IdentifierEdition},
+			args:    args{id: "999999099999999", edition: salesforceid.PostSummer23IdentifierEdition},
+			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("999"), PodIdentifier: []byte("999"), Reserved: []byte("0"), NumericIdentifier: []byte("99999999"), Suffix: []byte("AAA"), Edition: salesforceid.PostSummer23IdentifierEdition},
 			wantErr: false,
 		},
 		{
 			name:    "generates suffix (all a's)",
-			args:    args{id: "aaaaaaaaaaaaaaa", edition: salesforceid.PostSummer23IdentifierEdition},
-			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("aaa"), PodIdentifier: []byte("aaa"), Reserved: []byte("a"), NumericIdentifier: []byte("aaaaaaaa"), Suffix: []byte("AAA"), Edition: salesforceid.PostSummer23IdentifierEdition},
+			args:    args{id: "aaaaaa0aaaaaaaa", edition: salesforceid.PostSummer23IdentifierEdition},
+			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("aaa"), PodIdentifier: []byte("aaa"), Reserved: []byte("0"), NumericIdentifier: []byte("aaaaaaaa"), Suffix: []byte("AAA"), Edition: salesforceid.PostSummer23IdentifierEdition},
 			wantErr: false,
 		},
 		{
 			name:    "generates suffix (all z's)",
-			args:    args{id: "zzzzzzzzzzzzzzz", edition: salesforceid.PostSummer23IdentifierEdition},
-			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("zzz"), PodIdentifier: []byte("zzz"), Reserved: []byte("z"), NumericIdentifier: []byte("zzzzzzzz"), Suffix: []byte("AAA"), Edition: salesforceid.PostSummer23IdentifierEdition},
+			args:    args{id: "zzzzzz0zzzzzzzz", edition: salesforceid.PostSummer23IdentifierEdition},
+			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("zzz"), PodIdentifier: []byte("zzz"), Reserved: []byte("0"), NumericIdentifier: []byte("zzzzzzzz"), Suffix: []byte("AAA"), Edition: salesforceid.PostSummer23IdentifierEdition},
 			wantErr: false,
 		},
 		{
 			name:    "generates suffix (all A's)",
-			args:    args{id: "AAAAAAAAAAAAAAA", edition: salesforceid.PostSummer23IdentifierEdition},
-			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("AAA"), PodIdentifier: []byte("AAA"), Reserved: []byte("A"), NumericIdentifier: []byte("AAAAAAAA"), Suffix: []byte("555"), Edition: salesforceid.PostSummer23IdentifierEdition},
+			args:    args{id: "AAAAAA0AAAAAAAA", edition: salesforceid.PostSummer23IdentifierEdition},
+			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("AAA"), PodIdentifier: []byte("AAA"), Reserved: []byte("0"), NumericIdentifier: []byte("AAAAAAAA"), Suffix: []byte("535"), Edition: salesforceid.PostSummer23IdentifierEdition},
 			wantErr: false,
 		},
 		{
 			name:    "generates suffix (all Z's)",
-			args:    args{id: "ZZZZZZZZZZZZZZZ", edition: salesforceid.PostSummer23IdentifierEdition},
-			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("ZZZ"), PodIdentifier: []byte("ZZZ"), Reserved: []byte("Z"), NumericIdentifier: []byte("ZZZZZZZZ"), Suffix: []byte("555"), Edition: salesforceid.PostSummer23IdentifierEdition},
+			args:    args{id: "ZZZZZZ0ZZZZZZZZ", edition: salesforceid.PostSummer23IdentifierEdition},
+			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("ZZZ"), PodIdentifier: []byte("ZZZ"), Reserved: []byte("0"), NumericIdentifier: []byte("ZZZZZZZZ"), Suffix: []byte("535"), Edition: salesforceid.PostSummer23IdentifierEdition},
 			wantErr: false,
 		},
 		{
 			name:    "corrects casing with check suffix (all 15 characters are wrong - lower a instead of upper A)",
-			args:    args{id: "aaaaaaaaaaaaaaa555", edition: salesforceid.PostSummer23IdentifierEdition},
-			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("AAA"), PodIdentifier: []byte("AAA"), Reserved: []byte("A"), NumericIdentifier: []byte("AAAAAAAA"), Suffix: []byte("555"), Edition: salesforceid.PostSummer23IdentifierEdition},
+			args:    args{id: "aaaaaa0aaaaaaaa535", edition: salesforceid.PostSummer23IdentifierEdition},
+			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("AAA"), PodIdentifier: []byte("AAA"), Reserved: []byte("0"), NumericIdentifier: []byte("AAAAAAAA"), Suffix: []byte("535"), Edition: salesforceid.PostSummer23IdentifierEdition},
 			wantErr: false,
 		},
 		{
 			name:    "does nothing",
-			args:    args{id: "ZZZZZZZZZZZZZZZ555", edition: salesforceid.PostSummer23IdentifierEdition},
-			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("ZZZ"), PodIdentifier: []byte("ZZZ"), Reserved: []byte("Z"), NumericIdentifier: []byte("ZZZZZZZZ"), Suffix: []byte("555"), Edition: salesforceid.PostSummer23IdentifierEdition},
+			args:    args{id: "ZZZZZZ0ZZZZZZZZ535", edition: salesforceid.PostSummer23IdentifierEdition},
+			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("ZZZ"), PodIdentifier: []byte("ZZZ"), Reserved: []byte("0"), NumericIdentifier: []byte("ZZZZZZZZ"), Suffix: []byte("535"), Edition: salesforceid.PostSummer23IdentifierEdition},
 			wantErr: false,
 		},
 		{
 			name:    "corrects casing with check suffix (all 15 characters are wrong - lower z instead of upper Z)",
-			args:    args{id: "zzzzzzzzzzzzzzz555", edition: salesforceid.PostSummer23IdentifierEdition},
-			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("ZZZ"), PodIdentifier: []byte("ZZZ"), Reserved: []byte("Z"), NumericIdentifier: []byte("ZZZZZZZZ"), Suffix: []byte("555"), Edition: salesforceid.PostSummer23IdentifierEdition},
+			args:    args{id: "zzzzzz0zzzzzzzz535", edition: salesforceid.PostSummer23IdentifierEdition},
+			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("ZZZ"), PodIdentifier: []byte("ZZZ"), Reserved: []byte("0"), NumericIdentifier: []byte("ZZZZZZZZ"), Suffix: []byte("535"), Edition: salesforceid.PostSummer23IdentifierEdition},
 			wantErr: false,
 		},
 		{
 			name:    "corrects casing with check suffix (all 15 characters are wrong - upper Z instead of lower z)",
-			args:    args{id: "ZZZZZZZZZZZZZZZAAA", edition: salesforceid.PostSummer23IdentifierEdition},
-			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("zzz"), PodIdentifier: []byte("zzz"), Reserved: []byte("z"), NumericIdentifier: []byte("zzzzzzzz"), Suffix: []byte("AAA"), Edition: salesforceid.PostSummer23IdentifierEdition},
+			args:    args{id: "ZZZZZZ0ZZZZZZZZAAA", edition: salesforceid.PostSummer23IdentifierEdition},
+			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("zzz"), PodIdentifier: []byte("zzz"), Reserved: []byte("0"), NumericIdentifier: []byte("zzzzzzzz"), Suffix: []byte("AAA"), Edition: salesforceid.PostSummer23IdentifierEdition},
 			wantErr: false,
 		},
 		{
 			name:    "corrects casing with check suffix (intermittently wrong casing - upper Z instead of lower z)",
-			args:    args{id: "ZzZzZzZzZZzZZzzAAA", edition: salesforceid.PostSummer23IdentifierEdition},
-			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("zzz"), PodIdentifier: []byte("zzz"), Reserved: []byte("z"), NumericIdentifier: []byte("zzzzzzzz"), Suffix: []byte("AAA"), Edition: salesforceid.PostSummer23IdentifierEdition},
+			args:    args{id: "ZzZzZz0zZZzZZzzAAA", edition: salesforceid.PostSummer23IdentifierEdition},
+			want:    &salesforceid.SalesforceID{KeyPrefix: []byte("zzz"), PodIdentifier: []byte("zzz"), Reserved: []byte("0"), NumericIdentifier: []byte("zzzzzzzz"), Suffix: []byte("AAA"), Edition: salesforceid.PostSummer23IdentifierEdition},
 			wantErr: false,
 		},
+		{
+			name:    "errors when reserved byte is not 0",
+			args:    args{id: "999999999999999", edition: salesforceid.PostSummer23IdentifierEdition},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "errors when identifier is 14 characters",
 			args:    args{id: "ZZZZZZZZZZZZZZ", edition: salesforceid.PostSummer23IdentifierEdition},
@@ -466,7 +537,7 @@ func TestParse(t *testing.T) {
 			if tt.wantErr {
 				return
 			}
-			if diff := cmp.Diff(got, tt.want, cmpopts.IgnoreUnexported(salesforceid.SalesforceID{})); diff != "" {
+			if diff := cmp.Diff(got, tt.want, compareFields); diff != "" {
 				t.Errorf("Parse() = %+v, want %+v, diff = %s", got, tt.want, diff)
 			}
 		})
@@ -487,8 +558,182 @@ func BenchmarkNewV2(b *testing.B) {
 		})
 		b.Run("everything needs correction", func(b *testing.B) {
 			for i := 0; i < b.N; i++ {
-				_, _ = salesforceid.Parse("zzzzzzzzzzzzzzz555", salesforceid.PostSummer23IdentifierEdition)
+				_, _ = salesforceid.Parse("zzzzzz0zzzzzzzz535", salesforceid.PostSummer23IdentifierEdition)
 			}
 		})
 	})
 }
+
+func TestParseAuto(t *testing.T) {
+	testCases := []struct {
+		sfid           string
+		wantEdition    salesforceid.IdentifierEdition
+		wantConfidence salesforceid.Confidence
+		wantPod        string
+		wantErr        bool
+	}{
+		{"0011Ab000000062", salesforceid.PostSummer23IdentifierEdition, salesforceid.HighConfidence, "1Ab", false},
+		{"001300000000062", salesforceid.PreSummer23IdentifierEdition, salesforceid.MediumConfidence, "30", false},
+		{"001zz0000000062", salesforceid.PreSummer23IdentifierEdition, salesforceid.LowConfidence, "zz", false},
+		{"00100000000006", 0, 0, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.sfid, func(t *testing.T) {
+			got, confidence, err := salesforceid.ParseAuto(tc.sfid)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseAuto() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got.Edition != tc.wantEdition {
+				t.Errorf("ParseAuto() edition = %d, want %d", got.Edition, tc.wantEdition)
+			}
+			if confidence != tc.wantConfidence {
+				t.Errorf("ParseAuto() confidence = %d, want %d", confidence, tc.wantConfidence)
+			}
+			if string(got.PodIdentifier) != tc.wantPod {
+				t.Errorf("ParseAuto() pod = %s, want %s", got.PodIdentifier, tc.wantPod)
+			}
+
+			parsed, err := salesforceid.Parse(tc.sfid, salesforceid.AutoIdentifierEdition)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if diff := cmp.Diff(parsed, got, compareFields); diff != "" {
+				t.Errorf("Parse(AutoIdentifierEdition) differs from ParseAuto(), diff = %s", diff)
+			}
+		})
+	}
+}
+
+func TestSalesforceID_arithmeticPreservesEdition(t *testing.T) {
+	testCases := []struct {
+		name         string
+		sfid         string
+		edition      salesforceid.IdentifierEdition
+		op           func(*salesforceid.SalesforceID) (*salesforceid.SalesforceID, error)
+		want         string
+		wantPod      string
+		wantReserved string
+	}{
+		{
+			name:         "add (pre Summer '23)",
+			sfid:         "001300000000000",
+			edition:      salesforceid.PreSummer23IdentifierEdition,
+			op:           func(s *salesforceid.SalesforceID) (*salesforceid.SalesforceID, error) { return s.Add(62) },
+			want:         "001300000000010AAA",
+			wantPod:      "30",
+			wantReserved: "00",
+		},
+		{
+			name:         "add (post Summer '23)",
+			sfid:         "0011Ab000000000",
+			edition:      salesforceid.PostSummer23IdentifierEdition,
+			op:           func(s *salesforceid.SalesforceID) (*salesforceid.SalesforceID, error) { return s.Add(62) },
+			want:         "0011Ab000000010QAA",
+			wantPod:      "1Ab",
+			wantReserved: "0",
+		},
+		{
+			name:         "subtract (pre Summer '23)",
+			sfid:         "001300000000010",
+			edition:      salesforceid.PreSummer23IdentifierEdition,
+			op:           func(s *salesforceid.SalesforceID) (*salesforceid.SalesforceID, error) { return s.Subtract(1) },
+			want:         "00130000000000zAAA",
+			wantPod:      "30",
+			wantReserved: "00",
+		},
+		{
+			name:         "subtract (post Summer '23)",
+			sfid:         "0011Ab000000010",
+			edition:      salesforceid.PostSummer23IdentifierEdition,
+			op:           func(s *salesforceid.SalesforceID) (*salesforceid.SalesforceID, error) { return s.Subtract(1) },
+			want:         "0011Ab00000000zQAA",
+			wantPod:      "1Ab",
+			wantReserved: "0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sfid, err := salesforceid.Parse(tc.sfid, tc.edition)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tc.sfid, err)
+			}
+			got, err := tc.op(sfid)
+			if err != nil {
+				t.Fatalf("didn't expect an error but got %q", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("wanted %s, got %s", tc.want, got)
+			}
+			if got.Edition != tc.edition {
+				t.Errorf("wanted edition %d, got %d", tc.edition, got.Edition)
+			}
+			if string(got.PodIdentifier) != tc.wantPod {
+				t.Errorf("wanted pod identifier %s, got %s", tc.wantPod, got.PodIdentifier)
+			}
+			if string(got.Reserved) != tc.wantReserved {
+				t.Errorf("wanted reserved %s, got %s", tc.wantReserved, got.Reserved)
+			}
+		})
+	}
+}
+
+func TestParse_parseError(t *testing.T) {
+	testCases := []struct {
+		sfid string
+		want salesforceid.ParseError
+	}{
+		{
+			sfid: "00D00000000006",
+			want: salesforceid.ParseError{Input: "00D00000000006", Offset: -1, Reason: "got 14 characters", Err: salesforceid.ErrInvalidLengthSFID},
+		},
+		{
+			sfid: "001000000000062EAA",
+			want: salesforceid.ParseError{Input: "001000000000062EAA", Offset: 2, Expected: 'A', Actual: 'E', Reason: "'1' at offset 2 must be a letter for check byte 'E' at offset 15", Err: salesforceid.ErrInvalidSFID},
+		},
+		{
+			sfid: "0D1000000000062gaa",
+			want: salesforceid.ParseError{Input: "0D1000000000062gaa", Offset: 2, Expected: 'C', Actual: 'g', Reason: "'1' at offset 2 must be a letter for check byte 'g' at offset 15", Err: salesforceid.ErrInvalidSFID},
+		},
+		{
+			sfid: "0010000000000627AA",
+			want: salesforceid.ParseError{Input: "0010000000000627AA", Offset: 15, Actual: '7', Reason: "'7' at offset 15 is not a check byte", Err: salesforceid.ErrInvalidSFID},
+		},
+		{
+			sfid: "001000000000-62",
+			want: salesforceid.ParseError{Input: "001000000000-62", Offset: 12, Actual: '-', Reason: "'-' at offset 12 is not a base62 character", Err: salesforceid.ErrInvalidCharacter},
+		},
+		{
+			sfid: "001000000000062!AA",
+			want: salesforceid.ParseError{Input: "001000000000062!AA", Offset: 15, Actual: '!', Reason: "'!' at offset 15 is not a base62 character", Err: salesforceid.ErrInvalidCharacter},
+		},
+		{
+			sfid: "00130\xe900000abCd",
+			want: salesforceid.ParseError{Input: "00130\xe900000abCd", Offset: 5, Actual: 0xe9, Reason: "'é' at offset 5 is not a base62 character", Err: salesforceid.ErrInvalidCharacter},
+		},
+		{
+			sfid: "001300a00000062",
+			want: salesforceid.ParseError{Input: "001300a00000062", Offset: 6, Reason: "reserved byte 'a' at offset 6 must be '0'", Err: salesforceid.ErrInvalidReserved},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.sfid, func(t *testing.T) {
+			_, err := salesforceid.New(tc.sfid)
+			var got *salesforceid.ParseError
+			if !errors.As(err, &got) {
+				t.Fatalf("expected a ParseError but got %v", err)
+			}
+			if *got != tc.want {
+				t.Errorf("New(%q) error = %+v, want %+v", tc.sfid, *got, tc.want)
+			}
+			if !errors.Is(err, tc.want.Err) {
+				t.Errorf("expected err %q but got %q", tc.want.Err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package salesforceid
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// confusableRunes maps Cyrillic and Greek letters to the ASCII letters they
+// look like.
+var confusableRunes = map[rune]byte{
+	// Cyrillic
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O',
+	'Р': 'P', 'С': 'C', 'Т': 'T', 'Х': 'X', 'Ѕ': 'S', 'І': 'I', 'Ј': 'J',
+	'а': 'a', 'е': 'e', 'о': 'o', 'р': 'p', 'с': 'c', 'у': 'y', 'х': 'x',
+	'ѕ': 's', 'і': 'i', 'ј': 'j', 'ԛ': 'q', 'ԝ': 'w',
+	// Greek
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K',
+	'Μ': 'M', 'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+	'ο': 'o', 'ν': 'v',
+}
+
+// quoteRunes are quotation marks that surround identifiers copied from
+// documents and chat.
+const quoteRunes = "'\"`‘’‚‛“”„‟‹›«»′″"
+
+// Substitution records a change made by [Sanitize].
+type Substitution struct {
+	// Offset is the byte offset of Original in the input
+	Offset int
+	// Original is the character that was replaced or removed
+	Original rune
+	// Replacement is the ASCII byte Original was replaced with or 0 if it was
+	// removed
+	Replacement byte
+}
+
+// Sanitize converts an identifier copied from a document or chat to ASCII. It
+// removes whitespace, invisible characters such as zero-width spaces, and
+// quotation marks, replaces full-width characters with their ASCII forms, and
+// replaces Cyrillic and Greek letters with the ASCII letters they look like.
+// Every change is reported in the order it was made. Other characters are
+// left for [Parse] to reject.
+func Sanitize(id string) (string, []Substitution) {
+	var b strings.Builder
+	var substitutions []Substitution
+	for offset := 0; offset < len(id); {
+		start := offset
+		r, size := utf8.DecodeRuneInString(id[offset:])
+		original := id[offset : offset+size]
+		offset += size
+		var replacement byte
+		switch {
+		case r > ' ' && r < utf8.RuneSelf && !strings.ContainsRune(quoteRunes, r):
+			b.WriteString(original)
+			continue
+		case 0xFF01 <= r && r <= 0xFF5E:
+			replacement = byte(r - 0xFEE0)
+		case confusableRunes[r] != 0:
+			replacement = confusableRunes[r]
+		case !unicode.IsSpace(r) && !unicode.Is(unicode.Cf, r) && !strings.ContainsRune(quoteRunes, r):
+			b.WriteString(original)
+			continue
+		}
+		if strings.IndexByte(quoteRunes, replacement) >= 0 {
+			replacement = 0
+		}
+		if replacement != 0 {
+			b.WriteByte(replacement)
+		}
+		substitutions = append(substitutions, Substitution{Offset: start, Original: r, Replacement: replacement})
+	}
+	return b.String(), substitutions
+}
@@ -0,0 +1,132 @@
+package salesforceid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sigmavirus24/salesforceid"
+)
+
+func TestSanitize(t *testing.T) {
+	testCases := []struct {
+		name              string
+		input             string
+		want              string
+		wantSubstitutions []salesforceid.Substitution
+	}{
+		{
+			name:  "ascii",
+			input: "00D000000000062",
+			want:  "00D000000000062",
+		},
+		{
+			name:  "full-width",
+			input: "００Ｄ000000000062",
+			want:  "00D000000000062",
+			wantSubstitutions: []salesforceid.Substitution{
+				{Offset: 0, Original: '０', Replacement: '0'},
+				{Offset: 3, Original: '０', Replacement: '0'},
+				{Offset: 6, Original: 'Ｄ', Replacement: 'D'},
+			},
+		},
+		{
+			name:  "zero-width and non-breaking spaces",
+			input: "\u200b00D000000000062\u00a0\ufeff",
+			want:  "00D000000000062",
+			wantSubstitutions: []salesforceid.Substitution{
+				{Offset: 0, Original: '\u200b'},
+				{Offset: 18, Original: '\u00a0'},
+				{Offset: 20, Original: '\ufeff'},
+			},
+		},
+		{
+			name:  "smart quotes",
+			input: "“00D000000000062”",
+			want:  "00D000000000062",
+			wantSubstitutions: []salesforceid.Substitution{
+				{Offset: 0, Original: '“'},
+				{Offset: 18, Original: '”'},
+			},
+		},
+		{
+			name:  "full-width quotes",
+			input: "＂00D000000000062＂",
+			want:  "00D000000000062",
+			wantSubstitutions: []salesforceid.Substitution{
+				{Offset: 0, Original: '＂'},
+				{Offset: 18, Original: '＂'},
+			},
+		},
+		{
+			name:  "cyrillic and greek",
+			input: "003D0000001аН2Α",
+			want:  "003D0000001aH2A",
+			wantSubstitutions: []salesforceid.Substitution{
+				{Offset: 11, Original: 'а', Replacement: 'a'},
+				{Offset: 13, Original: 'Н', Replacement: 'H'},
+				{Offset: 16, Original: 'Α', Replacement: 'A'},
+			},
+		},
+		{
+			name:  "other characters are kept",
+			input: "00D00000000006\xff—",
+			want:  "00D00000000006\xff—",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, substitutions := salesforceid.Sanitize(tc.input)
+			if got != tc.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tc.input, got, tc.want)
+			}
+			if diff := cmp.Diff(tc.wantSubstitutions, substitutions); diff != "" {
+				t.Errorf("Sanitize(%q) substitutions mismatch (-want +got):\n%s", tc.input, diff)
+			}
+		})
+	}
+}
+
+func TestParseWithOptions(t *testing.T) {
+	testCases := []struct {
+		name              string
+		input             string
+		opts              salesforceid.ParseOptions
+		want              string
+		wantSubstitutions int
+		wantErr           error
+	}{
+		{"default", "0011Ab000000062", salesforceid.ParseOptions{}, "0011Ab000000062QAA", 0, nil},
+		{"edition", "0011Ab000000062", salesforceid.ParseOptions{Edition: salesforceid.PostSummer23IdentifierEdition}, "0011Ab000000062QAA", 0, nil},
+		{"sanitized", " ００Ｄ000000000062 ", salesforceid.ParseOptions{Sanitize: true}, "00D000000000062EAA", 5, nil},
+		{"not sanitized", "００Ｄ000000000062", salesforceid.ParseOptions{}, "", 0, salesforceid.ErrInvalidLengthSFID},
+		{"sanitized but invalid", "“00D00000000006”", salesforceid.ParseOptions{Sanitize: true}, "", 2, salesforceid.ErrInvalidLengthSFID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			id, substitutions, err := salesforceid.ParseWithOptions(tc.input, tc.opts)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected err %q but got %q", tc.wantErr, err)
+			}
+			if len(substitutions) != tc.wantSubstitutions {
+				t.Errorf("ParseWithOptions(%q) made %d substitutions, want %d", tc.input, len(substitutions), tc.wantSubstitutions)
+			}
+			if err != nil {
+				return
+			}
+			if got := id.String(); got != tc.want {
+				t.Errorf("ParseWithOptions(%q) = %s, want %s", tc.input, got, tc.want)
+			}
+			wantEdition := tc.opts.Edition
+			if wantEdition == 0 {
+				detected, _, _ := salesforceid.ParseAuto(tc.want)
+				wantEdition = detected.Edition
+			}
+			if id.Edition != wantEdition {
+				t.Errorf("ParseWithOptions(%q).Edition = %d, want %d", tc.input, id.Edition, wantEdition)
+			}
+		})
+	}
+}
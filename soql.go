@@ -0,0 +1,112 @@
+package salesforceid
+
+import (
+	"bytes"
+	"strings"
+)
+
+// MaxWhereClauseLength is the maximum number of characters Salesforce allows
+// in the WHERE clause of a SOQL query.
+// See also:
+// * https://developer.salesforce.com/docs/atlas.en-us.salesforce_app_limits_cheatsheet.meta/salesforce_app_limits_cheatsheet/salesforce_app_limits_platform_soslsoql.htm
+const MaxWhereClauseLength = 4000
+
+// RangeClause renders r as a SOQL condition, e.g.,
+// `Id >= '001000000000000AAA' AND Id <= '00100000000132FAAQ'`.
+func RangeClause(r IDRange) (string, error) {
+	if _, _, err := rangeBounds(r.Start, r.End); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString("Id >= ")
+	b.WriteString(quote(r.Start.String()))
+	b.WriteString(" AND Id <= ")
+	b.WriteString(quote(r.End.String()))
+	return b.String(), nil
+}
+
+// RangesClause renders ranges as a single SOQL condition matching any of
+// them, e.g., `(Id >= '...' AND Id <= '...') OR (Id >= '...' AND Id <= '...')`.
+// This returns [ErrMixedKeyPrefix] if the ranges do not all share the same
+// KeyPrefix and [ErrInvalidRange] if there are no ranges.
+func RangesClause(ranges ...IDRange) (string, error) {
+	if len(ranges) == 0 {
+		return "", ErrInvalidRange
+	}
+	clauses := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		clause, err := RangeClause(r)
+		if err != nil {
+			return "", err
+		}
+		if !bytes.Equal(r.Start.KeyPrefix, ranges[0].Start.KeyPrefix) {
+			return "", ErrMixedKeyPrefix
+		}
+		clauses = append(clauses, clause)
+	}
+	if len(clauses) == 1 {
+		return clauses[0], nil
+	}
+	return "(" + strings.Join(clauses, ") OR (") + ")", nil
+}
+
+// InClauses renders ids as SOQL conditions, e.g., `Id IN ('...', '...')`.
+// Each condition is at most maxLength characters long, splitting ids across
+// as many conditions as necessary. If maxLength is 0,
+// [MaxWhereClauseLength] is used. This returns [ErrMixedKeyPrefix] if the
+// identifiers do not all share the same KeyPrefix and
+// [ErrInvalidClauseLength] if maxLength is too short to fit a single
+// identifier.
+func InClauses(ids []*SalesforceID, maxLength int) ([]string, error) {
+	if maxLength == 0 {
+		maxLength = MaxWhereClauseLength
+	}
+	const (
+		prefix    = "Id IN ("
+		suffix    = ")"
+		separator = ", "
+	)
+	var clauses []string
+	var b strings.Builder
+	for _, id := range ids {
+		if !bytes.Equal(id.KeyPrefix, ids[0].KeyPrefix) {
+			return nil, ErrMixedKeyPrefix
+		}
+		quoted := quote(id.String())
+		if len(prefix)+len(quoted)+len(suffix) > maxLength {
+			return nil, ErrInvalidClauseLength
+		}
+		if b.Len() > 0 && b.Len()+len(separator)+len(quoted)+len(suffix) > maxLength {
+			b.WriteString(suffix)
+			clauses = append(clauses, b.String())
+			b.Reset()
+		}
+		if b.Len() == 0 {
+			b.WriteString(prefix)
+		} else {
+			b.WriteString(separator)
+		}
+		b.WriteString(quoted)
+	}
+	if b.Len() > 0 {
+		b.WriteString(suffix)
+		clauses = append(clauses, b.String())
+	}
+	return clauses, nil
+}
+
+// quote wraps s in single quotes, escaping the characters SOQL requires to be
+// escaped in a string literal.
+func quote(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('\'')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\'' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
@@ -0,0 +1,22 @@
+package salesforceid
+
+import "testing"
+
+func Test_quote(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{name: "identifier", s: "001000000000000AAA", want: "'001000000000000AAA'"},
+		{name: "single quote", s: "001'OR'1'='1", want: `'001\'OR\'1\'=\'1'`},
+		{name: "backslash", s: `001\`, want: `'001\\'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quote(tt.s); got != tt.want {
+				t.Errorf("quote(%v) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package salesforceid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sigmavirus24/salesforceid"
+)
+
+func mustNew(t *testing.T, id string) *salesforceid.SalesforceID {
+	t.Helper()
+	s, err := salesforceid.New(id)
+	if err != nil {
+		t.Fatalf("New(%q) error = %v", id, err)
+	}
+	return s
+}
+
+func TestRangesClause(t *testing.T) {
+	testCases := []struct {
+		name    string
+		ranges  [][2]string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "single range",
+			ranges: [][2]string{{"001000000000000", "00100000000132F"}},
+			want:   "Id >= '001000000000000AAA' AND Id <= '00100000000132FAAQ'",
+		},
+		{
+			name:   "multiple ranges",
+			ranges: [][2]string{{"001000000000000", "00100000000000z"}, {"001000000000100", "00100000000010z"}},
+			want:   "(Id >= '001000000000000AAA' AND Id <= '00100000000000zAAA') OR (Id >= '001000000000100AAA' AND Id <= '00100000000010zAAA')",
+		},
+		{
+			name:    "mixed key prefixes",
+			ranges:  [][2]string{{"001000000000000", "00100000000000z"}, {"003000000000000", "00300000000000z"}},
+			wantErr: salesforceid.ErrMixedKeyPrefix,
+		},
+		{
+			name:    "start after end",
+			ranges:  [][2]string{{"00100000000000z", "001000000000000"}},
+			wantErr: salesforceid.ErrInvalidRange,
+		},
+		{
+			name:    "no ranges",
+			wantErr: salesforceid.ErrInvalidRange,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var ranges []salesforceid.IDRange
+			for _, r := range tc.ranges {
+				ranges = append(ranges, salesforceid.IDRange{Start: mustNew(t, r[0]), End: mustNew(t, r[1])})
+			}
+			got, err := salesforceid.RangesClause(ranges...)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected err %v but got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("RangesClause() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInClauses(t *testing.T) {
+	testCases := []struct {
+		name      string
+		ids       []string
+		maxLength int
+		want      []string
+		wantErr   error
+	}{
+		{
+			name: "single clause",
+			ids:  []string{"001000000000000", "00100000000000z"},
+			want: []string{"Id IN ('001000000000000AAA', '00100000000000zAAA')"},
+		},
+		{
+			name:      "split at max length",
+			ids:       []string{"001000000000000", "001000000000001", "001000000000002"},
+			maxLength: len("Id IN ('001000000000000AAA', '001000000000001AAA')"),
+			want: []string{
+				"Id IN ('001000000000000AAA', '001000000000001AAA')",
+				"Id IN ('001000000000002AAA')",
+			},
+		},
+		{
+			name:      "max length too short",
+			ids:       []string{"001000000000000"},
+			maxLength: 20,
+			wantErr:   salesforceid.ErrInvalidClauseLength,
+		},
+		{
+			name:    "mixed key prefixes",
+			ids:     []string{"001000000000000", "003000000000000"},
+			wantErr: salesforceid.ErrMixedKeyPrefix,
+		},
+		{
+			name: "no identifiers",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var ids []*salesforceid.SalesforceID
+			for _, id := range tc.ids {
+				ids = append(ids, mustNew(t, id))
+			}
+			got, err := salesforceid.InClauses(ids, tc.maxLength)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected err %v but got %v", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("InClauses() = %v, want %v, diff = %s", got, tc.want, diff)
+			}
+			for _, clause := range got {
+				if tc.maxLength > 0 && len(clause) > tc.maxLength {
+					t.Errorf("len(%s) = %d, want at most %d", clause, len(clause), tc.maxLength)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package salesforceid
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+)
+
+// Scan implements [database/sql.Scanner]. It accepts 15 or 18 character
+// identifiers stored as strings or bytes. Trailing spaces are removed first
+// since 15 character identifiers stored in a CHAR(18) column are padded, and
+// a blank value scans as the zero value. The edition of every value is
+// detected as with [New] so rows of either edition can be scanned into the
+// same SalesforceID. Use [NullSalesforceID] for columns that may be NULL.
+func (s *SalesforceID) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return s.UnmarshalText(bytes.TrimRight([]byte(v), " "))
+	case []byte:
+		return s.UnmarshalText(bytes.TrimRight(v, " "))
+	case nil:
+		return ErrScanNull
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedScanType, src)
+	}
+}
+
+// Value implements [database/sql/driver.Valuer]. It produces the 18
+// character identifier or NULL for the zero value.
+func (s SalesforceID) Value() (driver.Value, error) {
+	if s.id == nil {
+		return nil, nil
+	}
+	return s.String(), nil
+}
+
+// NullSalesforceID represents a SalesforceID that may be NULL. It can be
+// used as a scan destination similar to [database/sql.NullString].
+type NullSalesforceID struct {
+	SalesforceID SalesforceID
+	Valid        bool // Valid is true if SalesforceID is not NULL
+}
+
+// Scan implements [database/sql.Scanner].
+func (n *NullSalesforceID) Scan(src any) error {
+	if src == nil {
+		n.SalesforceID, n.Valid = SalesforceID{}, false
+		return nil
+	}
+	if err := n.SalesforceID.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements [database/sql/driver.Valuer].
+func (n NullSalesforceID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.SalesforceID.Value()
+}
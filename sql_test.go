@@ -0,0 +1,116 @@
+package salesforceid_test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sigmavirus24/salesforceid"
+)
+
+func TestSalesforceID_Scan(t *testing.T) {
+	testCases := []struct {
+		name    string
+		src     any
+		want    string
+		wantErr error
+	}{
+		{"18 character string", "00D000000000062EAA", "00D000000000062EAA", nil},
+		{"15 character string", "00D000000000062", "00D000000000062EAA", nil},
+		{"padded CHAR(18)", "00D000000000062   ", "00D000000000062EAA", nil},
+		{"bytes", []byte("00d000000000062eaa"), "00D000000000062EAA", nil},
+		{"null", nil, "", salesforceid.ErrScanNull},
+		{"integer", int64(62), "", salesforceid.ErrUnsupportedScanType},
+		{"invalid length", "00D00000000006", "", salesforceid.ErrInvalidLengthSFID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got salesforceid.SalesforceID
+			err := got.Scan(tc.src)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Errorf("expected err %q but got %q", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("didn't expect an error but got %q", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("wanted %s, got %s", tc.want, got.String())
+			}
+			value, err := got.Value()
+			if err != nil {
+				t.Fatalf("didn't expect an error but got %q", err)
+			}
+			if value != tc.want {
+				t.Errorf("wanted value %s, got %v", tc.want, value)
+			}
+		})
+	}
+}
+
+func TestSalesforceID_Scan_blank(t *testing.T) {
+	got, _ := salesforceid.New("00D000000000062")
+	if err := got.Scan(strings.Repeat(" ", 18)); err != nil {
+		t.Fatalf("didn't expect an error but got %q", err)
+	}
+	value, err := got.Value()
+	if err != nil {
+		t.Fatalf("didn't expect an error but got %q", err)
+	}
+	if value != nil {
+		t.Errorf("wanted a NULL value, got %v", value)
+	}
+}
+
+func TestSalesforceID_Scan_rows(t *testing.T) {
+	rows := []string{"00D000000000062", "0011Ab000000062", "00130000000abCd", "0011Ab000000063"}
+	want := []string{"00D000000000062EAA", "0011Ab000000062QAA", "00130000000abCdAAI", "0011Ab000000063QAA"}
+	var id salesforceid.SalesforceID
+	var null salesforceid.NullSalesforceID
+	for i, row := range rows {
+		if err := id.Scan(row); err != nil {
+			t.Fatalf("didn't expect an error but got %q", err)
+		}
+		if err := null.Scan([]byte(row)); err != nil {
+			t.Fatalf("didn't expect an error but got %q", err)
+		}
+		if id.String() != want[i] || null.SalesforceID.String() != want[i] {
+			t.Errorf("wanted %s, got %s and %s", want[i], id.String(), null.SalesforceID.String())
+		}
+	}
+}
+
+func TestNullSalesforceID(t *testing.T) {
+	testCases := []struct {
+		name      string
+		src       any
+		wantValid bool
+		wantValue driver.Value
+	}{
+		{"valid", "00D000000000062", true, "00D000000000062EAA"},
+		{"null", nil, false, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got salesforceid.NullSalesforceID
+			if err := got.Scan(tc.src); err != nil {
+				t.Fatalf("didn't expect an error but got %q", err)
+			}
+			if got.Valid != tc.wantValid {
+				t.Errorf("wanted valid %v, got %v", tc.wantValid, got.Valid)
+			}
+			value, err := got.Value()
+			if err != nil {
+				t.Fatalf("didn't expect an error but got %q", err)
+			}
+			if value != tc.wantValue {
+				t.Errorf("wanted value %v, got %v", tc.wantValue, value)
+			}
+		})
+	}
+}
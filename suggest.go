@@ -0,0 +1,199 @@
+package salesforceid
+
+import (
+	"slices"
+	"strings"
+)
+
+// EditKind describes how a [Suggestion] differs from the identifier it was
+// suggested for. Kinds are ordered from the most to the least likely edit.
+type EditKind uint8
+
+const (
+	// CaseEdit changes the case of letters to match the check bytes.
+	CaseEdit EditKind = iota + 1
+	// SuffixEdit replaces one of the check bytes.
+	SuffixEdit
+	// ConfusableEdit replaces one byte with a byte that looks like it, e.g.,
+	// `O` for `0`.
+	ConfusableEdit
+	// TranspositionEdit swaps two adjacent bytes.
+	TranspositionEdit
+	// SubstitutionEdit replaces one byte with any other byte.
+	SubstitutionEdit
+)
+
+// confusableGroups are bytes that are easily mistaken for each other when
+// reading or typing an identifier.
+var confusableGroups = []string{"0OoDQ", "1IilL", "2Zz", "5Ss", "6Gb", "8B", "9gq", "UVuv", "mn"}
+
+// Suggestion is an identifier that could have been meant instead of the one
+// passed to [Suggest].
+type Suggestion struct {
+	ID   *SalesforceID
+	Kind EditKind
+	// Offset is the 0-based offset of the first byte that was changed
+	Offset int
+}
+
+// SuggestOptions configures [SuggestWithOptions].
+type SuggestOptions struct {
+	// Known limits suggestions to identifiers in the set.
+	Known *IDSet
+	// Limit is the maximum number of suggestions returned. If Limit is 0,
+	// every suggestion is returned.
+	Limit int
+	// Edition is used to parse suggestions. If Edition is 0, it is detected
+	// as with [New].
+	Edition IdentifierEdition
+}
+
+// Suggest returns the identifiers that are a single edit away from id and
+// whose case matches their check bytes. See [SuggestWithOptions].
+func Suggest(id string) []Suggestion {
+	return SuggestWithOptions(id, SuggestOptions{})
+}
+
+// SuggestWithOptions returns the identifiers that are a single edit away from
+// id, i.e., a change of case, a replaced check byte, a replaced byte, or two
+// swapped adjacent bytes, ranked by the [EditKind] of the edit and then by
+// its offset.
+//
+// An 18 character id is only a single edit away from identifiers whose case
+// matches their check bytes. This returns nil if id already matches its check
+// bytes and is in opts.Known, if given. A 15 character id has no check bytes
+// so suggestions are only returned for it when opts.Known is given.
+func SuggestWithOptions(id string, opts SuggestOptions) []Suggestion {
+	s := suggester{opts: opts, input: []byte(id), index: map[string]int{}}
+	if s.opts.Edition == 0 {
+		s.opts.Edition = AutoIdentifierEdition
+	}
+	switch len(id) {
+	case 18:
+	case 15:
+		if opts.Known == nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+	if s.consistent(s.input) {
+		parsed, err := Parse(id, s.opts.Edition)
+		if err == nil && (opts.Known == nil || opts.Known.Contains(parsed)) {
+			return nil
+		}
+	}
+
+	if len(id) == 18 {
+		c := slices.Clone(s.input)
+		if err := normalize(c); err == nil {
+			for i := range 15 {
+				if c[i] != s.input[i] {
+					s.add(c, CaseEdit, i)
+					break
+				}
+			}
+		}
+		expected := checkSuffix(s.input[:15])
+		for j, b := range expected {
+			c := slices.Clone(s.input)
+			c[15+j] = b
+			s.add(c, SuffixEdit, 15+j)
+		}
+	}
+	for i, original := range s.input[:15] {
+		for _, b := range table {
+			if b == original {
+				continue
+			}
+			c := slices.Clone(s.input)
+			c[i] = b
+			kind := SubstitutionEdit
+			switch {
+			case upper(b) == upper(original):
+				kind = CaseEdit
+			case confusable(b, original):
+				kind = ConfusableEdit
+			}
+			s.add(c, kind, i)
+		}
+	}
+	for i := 0; i < len(s.input)-1; i++ {
+		if s.input[i] == s.input[i+1] {
+			continue
+		}
+		c := slices.Clone(s.input)
+		c[i], c[i+1] = c[i+1], c[i]
+		s.add(c, TranspositionEdit, i)
+	}
+
+	slices.SortStableFunc(s.suggestions, func(a, b Suggestion) int {
+		if a.Kind != b.Kind {
+			return int(a.Kind) - int(b.Kind)
+		}
+		return a.Offset - b.Offset
+	})
+	if opts.Limit > 0 && len(s.suggestions) > opts.Limit {
+		return s.suggestions[:opts.Limit]
+	}
+	return s.suggestions
+}
+
+// suggester collects suggestions keeping only the most likely edit for each
+// identifier.
+type suggester struct {
+	opts        SuggestOptions
+	input       []byte
+	suggestions []Suggestion
+	index       map[string]int
+}
+
+// consistent reports whether the case of an 18 character candidate matches
+// its check bytes. Every 15 character candidate is consistent.
+func (s *suggester) consistent(c []byte) bool {
+	if len(c) == 15 {
+		return true
+	}
+	suffix := checkSuffix(c[:15])
+	for i, b := range c[15:] {
+		if upper(b) != suffix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *suggester) add(c []byte, kind EditKind, offset int) {
+	if !s.consistent(c) {
+		return
+	}
+	id, err := Parse(string(c), s.opts.Edition)
+	if err != nil || (s.opts.Known != nil && !s.opts.Known.Contains(id)) {
+		return
+	}
+	key := id.String()
+	if i, ok := s.index[key]; ok {
+		if kind < s.suggestions[i].Kind {
+			s.suggestions[i] = Suggestion{ID: id, Kind: kind, Offset: offset}
+		}
+		return
+	}
+	s.index[key] = len(s.suggestions)
+	s.suggestions = append(s.suggestions, Suggestion{ID: id, Kind: kind, Offset: offset})
+}
+
+func confusable(a, b byte) bool {
+	for _, group := range confusableGroups {
+		if strings.IndexByte(group, a) >= 0 && strings.IndexByte(group, b) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func upper(b byte) byte {
+	if 'a' <= b && b <= 'z' {
+		return b - 32
+	}
+	return b
+}
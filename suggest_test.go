@@ -0,0 +1,153 @@
+package salesforceid_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sigmavirus24/salesforceid"
+)
+
+type suggestion struct {
+	ID     string
+	Kind   salesforceid.EditKind
+	Offset int
+}
+
+func suggestions(got []salesforceid.Suggestion) []suggestion {
+	var s []suggestion
+	for _, g := range got {
+		s = append(s, suggestion{g.ID.String(), g.Kind, g.Offset})
+	}
+	return s
+}
+
+func TestSuggest(t *testing.T) {
+	testCases := []struct {
+		name      string
+		sfid      string
+		wantCount int
+		wantFirst []suggestion
+	}{
+		{
+			name:      "digit where the check bytes expect a letter",
+			sfid:      "001000000000062EAA",
+			wantCount: 27,
+			wantFirst: []suggestion{
+				{"001000000000062AAA", salesforceid.SuffixEdit, 15},
+				{"00I000000000062EAA", salesforceid.ConfusableEdit, 2},
+				{"00L000000000062EAA", salesforceid.ConfusableEdit, 2},
+				{"00A000000000062EAA", salesforceid.SubstitutionEdit, 2},
+			},
+		},
+		{
+			name:      "wrong case",
+			sfid:      "00d000000000062EAA",
+			wantCount: 27,
+			wantFirst: []suggestion{
+				{"00D000000000062EAA", salesforceid.CaseEdit, 2},
+				{"00d000000000062AAA", salesforceid.SuffixEdit, 15},
+				{"00A000000000062EAA", salesforceid.SubstitutionEdit, 2},
+				{"00B000000000062EAA", salesforceid.SubstitutionEdit, 2},
+			},
+		},
+		{
+			name:      "wrong case post summer 23",
+			sfid:      "0011ab000000062QAA",
+			wantCount: 27,
+			wantFirst: []suggestion{
+				{"0011Ab000000062QAA", salesforceid.CaseEdit, 4},
+				{"0011ab000000062AAA", salesforceid.SuffixEdit, 15},
+			},
+		},
+		{
+			name: "already valid",
+			sfid: "001000000000062AAA",
+		},
+		{
+			name: "15 characters without known identifiers",
+			sfid: "001000000000062",
+		},
+		{
+			name: "invalid length",
+			sfid: "00100000000006",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := salesforceid.Suggest(tc.sfid)
+			if len(got) != tc.wantCount {
+				t.Errorf("Suggest(%q) returned %d suggestions, want %d: %v", tc.sfid, len(got), tc.wantCount, suggestions(got))
+			}
+			if len(got) > len(tc.wantFirst) {
+				got = got[:len(tc.wantFirst)]
+			}
+			if diff := cmp.Diff(tc.wantFirst, suggestions(got)); diff != "" {
+				t.Errorf("Suggest(%q) mismatch (-want +got):\n%s", tc.sfid, diff)
+			}
+		})
+	}
+}
+
+func TestSuggestWithOptions(t *testing.T) {
+	known := &salesforceid.IDSet{}
+	for _, id := range []string{"00L000000000062", "001000000000063", "001000000000026"} {
+		if err := known.Add(mustNew(t, id)); err != nil {
+			t.Fatalf("didn't expect an error but got %q", err)
+		}
+	}
+	testCases := []struct {
+		name string
+		sfid string
+		opts salesforceid.SuggestOptions
+		want []suggestion
+	}{
+		{
+			name: "limit",
+			sfid: "001000000000062EAA",
+			opts: salesforceid.SuggestOptions{Limit: 2},
+			want: []suggestion{
+				{"001000000000062AAA", salesforceid.SuffixEdit, 15},
+				{"00I000000000062EAA", salesforceid.ConfusableEdit, 2},
+			},
+		},
+		{
+			name: "known",
+			sfid: "001000000000062EAA",
+			opts: salesforceid.SuggestOptions{Known: known},
+			want: []suggestion{{"00L000000000062EAA", salesforceid.ConfusableEdit, 2}},
+		},
+		{
+			name: "valid but unknown",
+			sfid: "001000000000062AAA",
+			opts: salesforceid.SuggestOptions{Known: known},
+			want: []suggestion{
+				{"001000000000026AAA", salesforceid.TranspositionEdit, 13},
+				{"001000000000063AAA", salesforceid.SubstitutionEdit, 14},
+			},
+		},
+		{
+			name: "15 characters",
+			sfid: "001000000000036",
+			opts: salesforceid.SuggestOptions{Known: known},
+			want: []suggestion{
+				{"001000000000063AAA", salesforceid.TranspositionEdit, 13},
+				{"001000000000026AAA", salesforceid.SubstitutionEdit, 13},
+			},
+		},
+		{
+			name: "known and valid",
+			sfid: "001000000000063AAA",
+			opts: salesforceid.SuggestOptions{Known: known},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := salesforceid.SuggestWithOptions(tc.sfid, tc.opts)
+			if diff := cmp.Diff(tc.want, suggestions(got)); diff != "" {
+				t.Errorf("SuggestWithOptions(%q) mismatch (-want +got):\n%s", tc.sfid, diff)
+			}
+		})
+	}
+}